@@ -0,0 +1,90 @@
+package mediator
+
+import (
+	"context"
+	"reflect"
+)
+
+// Request is implemented by request types that declare the type of result
+// returned by a handler for the request.  A request type implements
+// Request by embedding Returns:
+//
+//	type GetProduct struct {
+//	    mediator.Returns[*Product]
+//	    ProductId string
+//	}
+//
+// The result type of a request performed using Ask is then inferred from
+// the request, and a handler for the request type may only be registered
+// if it returns the declared result type.
+type Request[TResult any] interface {
+	returns(TResult)
+	resultType() reflect.Type
+}
+
+// Returns is embedded in a request type to declare the type of result
+// returned by a handler for the request (see Request).
+type Returns[TResult any] struct{}
+
+// returns identifies the declared result type, enabling the result type
+// to be inferred from a request type that embeds Returns.
+func (Returns[TResult]) returns(TResult) {}
+
+// resultType returns the declared result type
+func (Returns[TResult]) resultType() reflect.Type {
+	return typeOf[TResult]()
+}
+
+// declaresResult is implemented by any Request, regardless of the
+// declared result type.
+type declaresResult interface {
+	resultType() reflect.Type
+}
+
+// declaredResult returns the result type declared by a request type, if
+// the type implements Request.
+func declaredResult(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() == reflect.Interface || !t.Implements(typeOf[declaresResult]()) {
+		return nil, false
+	}
+
+	v := reflect.Zero(t)
+	if t.Kind() == reflect.Ptr {
+		v = reflect.New(t.Elem())
+	}
+	return v.Interface().(declaresResult).resultType(), true
+}
+
+// checkResult returns an InvalidHandlerError if the registered request
+// type declares a result type other than the result type of the handler.
+func checkResult(r *Registration) error {
+	if declared, ok := declaredResult(r.registeredtype); ok && declared != r.resulttype {
+		return InvalidHandlerError{
+			handlertype: reflect.TypeOf(r.implementation),
+			requesttype: r.registeredtype,
+			resulttype:  declared,
+		}
+	}
+	return nil
+}
+
+// Ask sends the specified request and context to the handler registered
+// with the default Mediator for the request type and returns the result and
+// error from that handler.
+//
+// Ask is equivalent to Perform, except that the result type is inferred
+// from the result type declared by the request (see Request), so that
+// neither the request nor result type need be specified:
+//
+//	product, err := mediator.Ask(ctx, GetProduct{ProductId: id})
+func Ask[TResult any](ctx context.Context, request Request[TResult]) (TResult, error) {
+	return AskOn(defaultMediator, ctx, request)
+}
+
+// AskOn sends the specified request and context to the handler registered
+// with the specified Mediator for the request type and returns the result
+// and error from that handler, inferring the result type from the request
+// in the same way as for Ask.
+func AskOn[TResult any](m *Mediator, ctx context.Context, request Request[TResult]) (TResult, error) {
+	return PerformOn[Request[TResult], TResult](m, ctx, request)
+}
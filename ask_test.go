@@ -0,0 +1,129 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type greeting struct {
+	Returns[string]
+	name string
+}
+
+type count struct {
+	Returns[int]
+}
+
+func TestAsk(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("infers the result type", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		RegisterHandlerOn[greeting, string](m, &mockhandler[greeting, string]{
+			execute: func(_ context.Context, rq greeting) (string, error) { return "hello " + rq.name, nil },
+		})
+
+		// ACT
+		result, err := AskOn(m, ctx, greeting{name: "world"})
+
+		// ASSERT
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if wanted, got := "hello world", result; wanted != got {
+			t.Errorf("wanted %q, got %q", wanted, got)
+		}
+	})
+
+	t.Run("pointer request", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		RegisterHandlerOn[*greeting, string](m, returning[*greeting]("pointer"))
+
+		// ACT
+		result, err := AskOn(m, ctx, &greeting{})
+
+		// ASSERT
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if wanted, got := "pointer", result; wanted != got {
+			t.Errorf("wanted %q, got %q", wanted, got)
+		}
+	})
+
+	t.Run("no handler", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+
+		// ACT
+		_, err := AskOn(m, ctx, count{})
+
+		// ASSERT
+		if err == nil {
+			t.Error("wanted error, got nil")
+		}
+	})
+
+	t.Run("default mediator", func(t *testing.T) {
+		// ARRANGE
+		reg := RegisterHandler[count, int](&mockhandler[count, int]{
+			execute: func(context.Context, count) (int, error) { return 42, nil },
+		})
+		defer reg.Remove()
+
+		// ACT
+		result, err := Ask(ctx, count{})
+
+		// ASSERT
+		if err != nil || result != 42 {
+			t.Errorf("wanted 42, got %d (error %v)", result, err)
+		}
+	})
+}
+
+func TestRegisterHandlerWithDeclaredResult(t *testing.T) {
+	t.Run("handler returning the declared result", func(t *testing.T) {
+		// ACT
+		_, err := TryRegisterHandlerOn[greeting, string](New(), returning[greeting]("ok"))
+
+		// ASSERT
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("handler returning a different result", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+
+		// ACT
+		reg, err := TryRegisterHandlerOn[greeting, int](m, &mockhandler[greeting, int]{})
+
+		// ASSERT
+		var invalid InvalidHandlerError
+		if !errors.As(err, &invalid) {
+			t.Errorf("wanted InvalidHandlerError, got %T (%[1]v)", err)
+		}
+		if reg != nil {
+			t.Error("wanted nil registration")
+		}
+		if m.handlers.len() != 0 {
+			t.Error("handler was registered")
+		}
+	})
+
+	t.Run("overriding with a different result panics", func(t *testing.T) {
+		// ARRANGE
+		defer func() {
+			if _, ok := recover().(InvalidHandlerError); !ok {
+				t.Error("did not panic with InvalidHandlerError")
+			}
+		}()
+
+		// ACT
+		OverrideHandlerOn[*greeting, int](New(), &mockhandler[*greeting, int]{})
+	})
+}
@@ -0,0 +1,277 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+// ErrShutdown is returned when attempting to perform a request or send
+// data asynchronously using a Mediator that has been shut down.
+var ErrShutdown = errors.New("mediator has been shut down")
+
+// Future[TResult] holds the result and error of a request performed (or
+// data sent) asynchronously, once available.
+type Future[TResult any] struct {
+	done   chan struct{}
+	result TResult
+	err    error
+}
+
+// newFuture returns a Future with no result
+func newFuture[TResult any]() *Future[TResult] {
+	return &Future[TResult]{done: make(chan struct{})}
+}
+
+// resolve sets the result and error of the Future
+func (f *Future[TResult]) resolve(result TResult, err error) {
+	f.result = result
+	f.err = err
+	close(f.done)
+}
+
+// Done returns a channel that is closed when the result of the Future
+// is available.
+func (f *Future[TResult]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Await waits for the result of the Future to be available, returning
+// the result and error of the request (or data).
+//
+// If the specified context is done before the result is available, the
+// zero value of TResult is returned together with the error from the
+// context.  Cancelling the context passed to Await does not cancel the
+// request; the context passed to PerformAsync or SendAsync is passed
+// to the handler or receiver and should be used for that purpose.
+func (f *Future[TResult]) Await(ctx context.Context) (TResult, error) {
+	select {
+	case <-f.done:
+		return f.result, f.err
+	case <-ctx.Done():
+		return *new(TResult), ctx.Err()
+	}
+}
+
+// WithWorkerPool is an Option that configures the size of the pool of
+// workers used by a Mediator to perform requests and send data
+// asynchronously, and the number of requests or data that may be queued
+// for those workers.
+//
+// The worker pool is started when first used; configuring the worker
+// pool after it has been started has no effect.
+//
+// If workers is less than 1, the number of workers is GOMAXPROCS.  If
+// queue is negative, the size of the queue is the same as the number of
+// workers.  If not configured, both defaults apply.
+func WithWorkerPool(workers int, queue int) Option {
+	return func(cfg *config) {
+		cfg.workers = workers
+		cfg.queue = queue
+	}
+}
+
+// pool is a pool of workers, processing jobs from a bounded queue.
+type pool struct {
+	mu       sync.RWMutex
+	start    sync.Once
+	stop     sync.Once
+	stopping chan struct{}
+	closed   bool
+	queue    chan func()
+	workers  sync.WaitGroup
+}
+
+// newPool returns a pool that has not yet been started
+func newPool() *pool {
+	return &pool{stopping: make(chan struct{})}
+}
+
+// submit adds a job to the queue of the pool, starting the pool if
+// necessary.  If the queue is full, submit blocks until the job can be
+// queued, the specified context is done or the pool is shut down.
+func (p *pool) submit(ctx context.Context, cfg *config, job func()) error {
+	p.start.Do(func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.closed {
+			return
+		}
+
+		workers := cfg.workers
+		if workers < 1 {
+			workers = runtime.GOMAXPROCS(0)
+		}
+		queue := cfg.queue
+		if queue < 0 {
+			queue = workers
+		}
+
+		p.queue = make(chan func(), queue)
+		p.workers.Add(workers)
+		for i := 0; i < workers; i++ {
+			go func() {
+				defer p.workers.Done()
+				for job := range p.queue {
+					job()
+				}
+			}()
+		}
+	})
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return ErrShutdown
+	}
+
+	select {
+	case p.queue <- job:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-p.stopping:
+		return ErrShutdown
+	}
+}
+
+// shutdown stops the pool accepting jobs and waits for all queued and
+// in-flight jobs to complete or the specified context to be done.
+func (p *pool) shutdown(ctx context.Context) error {
+	p.stop.Do(func() {
+		close(p.stopping)
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.closed = true
+		if p.queue != nil {
+			close(p.queue)
+		}
+	})
+
+	drained := make(chan struct{})
+	go func() {
+		p.workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// async submits a job to the worker pool of the Mediator which calls the
+// specified func if the context is not done when the job is processed,
+// resolving the returned Future with the result.
+//
+// A panic in the func is recovered, resolving the Future with a
+// HandlerPanicError (reported if the Mediator is configured with a
+// panic report func), rather than crashing the worker.
+func async[TResult any](m *Mediator, ctx context.Context, kind Kind, request interface{}, fn func() (TResult, error)) (*Future[TResult], error) {
+	f := newFuture[TResult]()
+	err := m.workers.submit(ctx, m.config(), func() {
+		if err := ctx.Err(); err != nil {
+			f.resolve(*new(TResult), err)
+			return
+		}
+
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			perr := HandlerPanicError{
+				kind:        kind,
+				requesttype: reflect.TypeOf(request),
+				value:       v,
+				stack:       debug.Stack(),
+			}
+			if report := m.config().report; report != nil {
+				report(ctx, perr)
+			}
+			f.resolve(*new(TResult), perr)
+		}()
+		f.resolve(fn())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// PerformAsync queues the specified request and context to be performed
+// by a worker of the default Mediator, returning a Future for the result
+// and error from the handler.
+//
+// If the queue of the worker pool is full, PerformAsync blocks until the
+// request can be queued or the context is done.  If the context is done
+// or the Mediator is shut down before the request can be queued then
+// a nil Future and an error is returned.
+//
+// The request is performed by the worker in the same way as Perform.
+// If the context is done before a worker performs the request, the
+// handler is not called and the Future resolves with the context error.
+//
+// A panic while performing the request is always recovered, whether or
+// not the Mediator is configured WithPanicRecovery; the Future resolves
+// with a HandlerPanicError.
+func PerformAsync[TRequest any, TResult any](ctx context.Context, request TRequest) (*Future[TResult], error) {
+	return PerformAsyncOn[TRequest, TResult](defaultMediator, ctx, request)
+}
+
+// PerformAsyncOn queues the specified request and context to be performed
+// by a worker of the specified Mediator, returning a Future for the result
+// and error from the handler.
+//
+// The request is queued and performed in the same way as for PerformAsync.
+func PerformAsyncOn[TRequest any, TResult any](m *Mediator, ctx context.Context, request TRequest) (*Future[TResult], error) {
+	return async(m, ctx, HandlerKind, request, func() (TResult, error) {
+		return PerformOn[TRequest, TResult](m, ctx, request)
+	})
+}
+
+// SendAsync queues the specified data and context to be sent to the
+// receiver by a worker of the default Mediator, returning a Future for
+// the error from the receiver.
+//
+// The data is queued in the same way as a request for PerformAsync and
+// sent by the worker in the same way as Send.  As for PerformAsync, a
+// panic in the receiver resolves the Future with a HandlerPanicError.
+func SendAsync[TData any](ctx context.Context, data TData) (*Future[struct{}], error) {
+	return SendAsyncOn(defaultMediator, ctx, data)
+}
+
+// SendAsyncOn queues the specified data and context to be sent to the
+// receiver by a worker of the specified Mediator, returning a Future for
+// the error from the receiver.
+//
+// The data is queued and sent in the same way as for SendAsync.
+func SendAsyncOn[TData any](m *Mediator, ctx context.Context, data TData) (*Future[struct{}], error) {
+	return async(m, ctx, ReceiverKind, data, func() (struct{}, error) {
+		return struct{}{}, SendOn(m, ctx, data)
+	})
+}
+
+// Shutdown shuts down the worker pool of the default Mediator.
+func Shutdown(ctx context.Context) error {
+	return defaultMediator.Shutdown(ctx)
+}
+
+// Shutdown shuts down the worker pool of the Mediator.  Any requests or
+// data already queued are processed, but no further requests or data may
+// be queued; PerformAsync and SendAsync will return ErrShutdown.
+//
+// Shutdown waits for all queued and in-flight requests and data to be
+// processed, returning nil, or the specified context to be done,
+// returning the error from the context.
+//
+// Shutdown does not affect requests performed or data sent synchronously.
+func (m *Mediator) Shutdown(ctx context.Context) error {
+	return m.workers.shutdown(ctx)
+}
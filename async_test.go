@@ -0,0 +1,339 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestThatPerformAsyncResolvesTheFutureWithTheResult(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	defer func() { _ = m.Shutdown(context.Background()) }()
+	RegisterHandlerOn[string, string](m, &mockhandler[string, string]{
+		execute: func(_ context.Context, rq string) (string, error) { return rq + ":result", nil },
+	})
+
+	// ACT
+
+	future, err := PerformAsyncOn[string, string](m, context.Background(), "request")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err := future.Await(context.Background())
+
+	// ASSERT
+
+	if err != nil || result != "request:result" {
+		t.Errorf("wanted %q, got %q (err: %v)", "request:result", result, err)
+	}
+}
+
+func TestThatSendAsyncResolvesTheFutureWithTheError(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	defer func() { _ = m.Shutdown(context.Background()) }()
+	failed := errors.New("failed")
+	RegisterReceiverOn[string](m, &mockreceiver[string]{
+		execute: func(context.Context, string) error { return failed },
+	})
+
+	// ACT
+
+	future, err := SendAsyncOn(m, context.Background(), "data")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-future.Done()
+	_, err = future.Await(context.Background())
+
+	// ASSERT
+
+	if err != failed {
+		t.Errorf("wanted %v, got %v", failed, err)
+	}
+}
+
+func TestThatAPanicInAnAsyncHandlerResolvesTheFuture(t *testing.T) {
+	t.Run("perform", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		defer func() { _ = m.Shutdown(context.Background()) }()
+		RegisterHandlerOn[string, string](m, &mockhandler[string, string]{
+			execute: func(context.Context, string) (string, error) { panic("boom") },
+		})
+
+		// ACT
+		future, err := PerformAsyncOn[string, string](m, context.Background(), "request")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err = future.Await(context.Background())
+
+		// ASSERT
+		perr := HandlerPanicError{}
+		if !errors.As(err, &perr) || !errors.Is(err, ErrHandlerPanic) {
+			t.Fatalf("wanted HandlerPanicError, got %T (%[1]v)", err)
+		}
+		if perr.Kind() != HandlerKind || perr.Value() != "boom" || len(perr.Stack()) == 0 {
+			t.Errorf("unexpected error details: %v, %v, %d byte stack", perr.Kind(), perr.Value(), len(perr.Stack()))
+		}
+	})
+
+	t.Run("send, with report", func(t *testing.T) {
+		// ARRANGE
+		var reported []HandlerPanicError
+		m := New(
+			WithPanicRecovery(func(_ context.Context, err HandlerPanicError) { reported = append(reported, err) }),
+			WithBehaviors(BehaviorFunc(func(context.Context, Dispatch, Next) (interface{}, error) { panic("behavior") })),
+		)
+		defer func() { _ = m.Shutdown(context.Background()) }()
+		RegisterReceiverOn[string](m, &mockreceiver[string]{})
+
+		// ACT
+		future, err := SendAsyncOn(m, context.Background(), "data")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err = future.Await(context.Background())
+
+		// ASSERT
+		if !errors.Is(err, ErrHandlerPanic) {
+			t.Errorf("wanted HandlerPanicError, got %T (%[1]v)", err)
+		}
+		if len(reported) != 1 || reported[0].Kind() != ReceiverKind {
+			t.Errorf("wanted 1 receiver panic reported, got %v", reported)
+		}
+	})
+}
+
+func TestThatAwaitReturnsWhenTheContextIsDone(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	release := make(chan struct{})
+	defer func() {
+		close(release)
+		_ = m.Shutdown(context.Background())
+	}()
+	RegisterHandlerOn[string, string](m, &mockhandler[string, string]{
+		execute: func(context.Context, string) (string, error) { <-release; return "result", nil },
+	})
+	future, _ := PerformAsyncOn[string, string](m, context.Background(), "request")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// ACT
+
+	result, err := future.Await(ctx)
+
+	// ASSERT
+
+	if !errors.Is(err, context.DeadlineExceeded) || result != "" {
+		t.Errorf("wanted %v (no result), got %v (result %q)", context.DeadlineExceeded, err, result)
+	}
+}
+
+func TestThatPerformAsyncBlocksWhenTheQueueIsFull(t *testing.T) {
+	// ARRANGE
+
+	m := New(WithWorkerPool(1, 1))
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	defer func() {
+		close(release)
+		_ = m.Shutdown(context.Background())
+	}()
+	RegisterHandlerOn[string, string](m, &mockhandler[string, string]{
+		execute: func(context.Context, string) (string, error) {
+			started <- struct{}{}
+			<-release
+			return "result", nil
+		},
+	})
+
+	// occupy the only worker, then fill the queue
+	if _, err := PerformAsyncOn[string, string](m, context.Background(), "running"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-started
+	if _, err := PerformAsyncOn[string, string](m, context.Background(), "queued"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// ACT
+
+	future, err := PerformAsyncOn[string, string](m, ctx, "blocked")
+
+	// ASSERT
+
+	if !errors.Is(err, context.DeadlineExceeded) || future != nil {
+		t.Errorf("wanted %v (no future), got %v (future %v)", context.DeadlineExceeded, err, future)
+	}
+}
+
+func TestThatAQueuedRequestIsNotPerformedIfTheContextIsCancelled(t *testing.T) {
+	// ARRANGE
+
+	m := New(WithWorkerPool(1, 1))
+	release := make(chan struct{})
+	defer func() { _ = m.Shutdown(context.Background()) }()
+	mock := &mockreceiver[string]{execute: func(_ context.Context, data string) error {
+		if data == "running" {
+			<-release
+		}
+		return nil
+	}}
+	RegisterReceiverOn[string](m, mock)
+
+	_, _ = SendAsyncOn(m, context.Background(), "running")
+	ctx, cancel := context.WithCancel(context.Background())
+	future, _ := SendAsyncOn(m, ctx, "queued")
+
+	// ACT
+
+	cancel()
+	close(release)
+	_, err := future.Await(context.Background())
+
+	// ASSERT
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("wanted %v, got %v", context.Canceled, err)
+	}
+	if mock.Received("queued") {
+		t.Error("receiver was called")
+	}
+}
+
+func TestThatShutdownDrainsQueuedRequests(t *testing.T) {
+	// ARRANGE
+
+	m := New(WithWorkerPool(2, 10))
+	count := int32(0)
+	RegisterReceiverOn[int](m, &mockreceiver[int]{execute: func(context.Context, int) error {
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&count, 1)
+		return nil
+	}})
+	for i := 0; i < 10; i++ {
+		if _, err := SendAsyncOn(m, context.Background(), i); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// ACT
+
+	err := m.Shutdown(context.Background())
+
+	// ASSERT
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := atomic.LoadInt32(&count); got != 10 {
+		t.Errorf("wanted 10 processed, got %d", got)
+	}
+
+	t.Run("rejects further requests", func(t *testing.T) {
+		_, err := SendAsyncOn(m, context.Background(), 42)
+		if !errors.Is(err, ErrShutdown) {
+			t.Errorf("wanted %v, got %v", ErrShutdown, err)
+		}
+	})
+
+	t.Run("may be called again", func(t *testing.T) {
+		if err := m.Shutdown(context.Background()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestThatShutdownReturnsWhenTheContextIsDone(t *testing.T) {
+	// ARRANGE
+
+	m := New(WithWorkerPool(1, 0))
+	release := make(chan struct{})
+	defer close(release)
+	RegisterReceiverOn[string](m, &mockreceiver[string]{execute: func(context.Context, string) error {
+		<-release
+		return nil
+	}})
+	_, _ = SendAsyncOn(m, context.Background(), "data")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// ACT
+
+	err := m.Shutdown(ctx)
+
+	// ASSERT
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("wanted %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestThatShutdownBeforeUseRejectsRequests(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+
+	// ACT
+
+	err := m.Shutdown(context.Background())
+
+	// ASSERT
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := PerformAsyncOn[string, string](m, context.Background(), "request"); !errors.Is(err, ErrShutdown) {
+		t.Errorf("wanted %v, got %v", ErrShutdown, err)
+	}
+}
+
+func TestThatShutdownReleasesBlockedRequests(t *testing.T) {
+	// ARRANGE
+
+	m := New(WithWorkerPool(1, 0))
+	release := make(chan struct{})
+	started := make(chan struct{})
+	RegisterReceiverOn[string](m, &mockreceiver[string]{execute: func(context.Context, string) error {
+		close(started)
+		<-release
+		return nil
+	}})
+	_, _ = SendAsyncOn(m, context.Background(), "running")
+	<-started
+
+	blocked := make(chan error)
+	go func() {
+		_, err := SendAsyncOn(m, context.Background(), "blocked")
+		blocked <- err
+	}()
+
+	// ACT
+
+	shutdown := make(chan error)
+	go func() { shutdown <- m.Shutdown(context.Background()) }()
+
+	// ASSERT
+
+	if err := <-blocked; !errors.Is(err, ErrShutdown) {
+		t.Errorf("wanted %v, got %v", ErrShutdown, err)
+	}
+	close(release)
+	if err := <-shutdown; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package mediator
+
+import (
+	"context"
+	"reflect"
+	"runtime/debug"
+	"time"
+)
+
+// Dispatch describes a request being performed by a handler or data
+// being sent to a receiver.
+type Dispatch struct {
+	// Kind identifies whether the request is being performed by
+	// a handler or sent to a receiver
+	Kind Kind
+
+	// Type is the type of the request or data
+	Type reflect.Type
+
+	// Request is the request or data value
+	Request interface{}
+}
+
+// Next is the continuation passed to a Behavior, to be called to
+// continue processing a request.  It returns the result and error
+// from the remaining behaviors and, ultimately, the handler or receiver.
+//
+// For a receiver, the result is always nil.
+type Next func(context.Context) (interface{}, error)
+
+// Behavior is the interface to be implemented by a pipeline
+// behavior, wrapping the processing of requests or data.
+//
+// A behavior is called with the context and a description of the
+// request being dispatched, together with the continuation to be
+// called to continue processing the request.  A behavior may modify
+// the context passed to the continuation, inspect or replace the
+// result and error returned by it, or not call it at all (in which case
+// it must provide the result and/or error itself).
+//
+// Any result returned by a behavior must be of the result type of the
+// handler for the request, otherwise a zero value result is returned
+// to the caller.
+//
+// Behaviors wrap, and are called before, any validation of the request
+// or data; validators are called (and the handler or receiver executed)
+// by the innermost continuation, after all behaviors.
+type Behavior interface {
+	Handle(context.Context, Dispatch, Next) (interface{}, error)
+}
+
+// BehaviorFunc is a func that implements Behavior.
+type BehaviorFunc func(context.Context, Dispatch, Next) (interface{}, error)
+
+// Handle calls the func.
+func (fn BehaviorFunc) Handle(ctx context.Context, d Dispatch, next Next) (interface{}, error) {
+	return fn(ctx, d, next)
+}
+
+// WithBehaviors is an Option that adds the specified behaviors to
+// the pipeline for all requests and data dispatched by a Mediator.
+//
+// Behaviors are called in the order in which they are added, with the
+// first behavior added being the outermost in the pipeline.
+func WithBehaviors(behaviors ...Behavior) Option {
+	return func(cfg *config) {
+		cfg.behaviors = append(cfg.behaviors[:len(cfg.behaviors):len(cfg.behaviors)], behaviors...)
+	}
+}
+
+// Behaviors is a RegistrationOption that adds the specified behaviors to
+// the pipeline for requests or data of the registered type.
+//
+// Behaviors added to a registration are called after any behaviors
+// added to the Mediator.
+func Behaviors(behaviors ...Behavior) RegistrationOption {
+	return func(r *Registration) {
+		r.behaviors = append(r.behaviors, behaviors...)
+	}
+}
+
+// Use adds the specified behaviors to the pipeline for all requests and
+// data dispatched by the default Mediator.
+func Use(behaviors ...Behavior) {
+	defaultMediator.Use(behaviors...)
+}
+
+// Use adds the specified behaviors to the pipeline for all requests and
+// data dispatched by the Mediator.  It is equivalent to configuring the
+// Mediator using WithBehaviors.
+func (m *Mediator) Use(behaviors ...Behavior) {
+	m.Configure(WithBehaviors(behaviors...))
+}
+
+// dispatch calls the behaviors of the Mediator followed by the behaviors
+// of the registration, before finally calling the specified func which
+// validates and executes the request or data.  Any observers are notified
+// of the start and end of the dispatch.
+//
+// If the dispatch panics, observers are notified of the end of the
+// dispatch with a HandlerPanicError before the panic is resumed.
+func (m *Mediator) dispatch(ctx context.Context, d Dispatch, r *Registration, execute Next) (result interface{}, err error) {
+	cfg := m.config()
+
+	next := chain(d, r.behaviors, execute)
+	next = chain(d, cfg.behaviors, next)
+
+	if len(cfg.observers) == 0 {
+		return next(ctx)
+	}
+
+	for _, o := range cfg.observers {
+		o.OnDispatchStart(ctx, d)
+	}
+	start := time.Now()
+	defer func() {
+		v := recover()
+		if v != nil {
+			err = HandlerPanicError{
+				kind:        d.Kind,
+				requesttype: d.Type,
+				value:       v,
+				stack:       debug.Stack(),
+			}
+		}
+
+		elapsed := time.Since(start)
+		for _, o := range cfg.observers {
+			o.OnDispatchEnd(ctx, d, err, elapsed)
+		}
+
+		if v != nil {
+			panic(v)
+		}
+	}()
+
+	return next(ctx)
+}
+
+// chain returns a Next that calls each of the specified behaviors in
+// turn, ending with a call to the specified Next.
+func chain(d Dispatch, behaviors []Behavior, next Next) Next {
+	for i := len(behaviors) - 1; i >= 0; i-- {
+		b, n := behaviors[i], next
+		next = func(ctx context.Context) (interface{}, error) {
+			return b.Handle(ctx, d, n)
+		}
+	}
+	return next
+}
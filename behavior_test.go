@@ -0,0 +1,236 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// recorder returns a Behavior that appends the specified name to
+// a slice of calls, before and after calling the next behavior
+func recorder(name string, calls *[]string) Behavior {
+	return BehaviorFunc(func(ctx context.Context, d Dispatch, next Next) (interface{}, error) {
+		*calls = append(*calls, name+":before")
+		result, err := next(ctx)
+		*calls = append(*calls, name+":after")
+		return result, err
+	})
+}
+
+func TestThatBehaviorsAreCalledInOrder(t *testing.T) {
+	// ARRANGE
+
+	calls := []string{}
+	m := New(WithBehaviors(recorder("global-1", &calls)))
+	m.Use(recorder("global-2", &calls))
+
+	RegisterHandlerOn[string, string](m, &mockhandler[string, string]{
+		execute: func(context.Context, string) (string, error) {
+			calls = append(calls, "execute")
+			return "result", nil
+		},
+	}, Behaviors(recorder("request", &calls)))
+
+	// ACT
+
+	result, err := PerformOn[string, string](m, context.Background(), "request")
+
+	// ASSERT
+
+	if err != nil || result != "result" {
+		t.Fatalf("wanted %q, got %q (err: %v)", "result", result, err)
+	}
+
+	wanted := []string{
+		"global-1:before",
+		"global-2:before",
+		"request:before",
+		"execute",
+		"request:after",
+		"global-2:after",
+		"global-1:after",
+	}
+	got := calls
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %v\ngot    %v", wanted, got)
+	}
+}
+
+func TestThatRegistrationBehaviorsApplyOnlyToTheRegisteredType(t *testing.T) {
+	// ARRANGE
+
+	calls := []string{}
+	m := New()
+	RegisterReceiverOn[string](m, &mockreceiver[string]{execute: func(context.Context, string) error { return nil }}, Behaviors(recorder("string", &calls)))
+	RegisterReceiverOn[int](m, &mockreceiver[int]{execute: func(context.Context, int) error { return nil }})
+
+	// ACT
+
+	err := SendOn(m, context.Background(), 42)
+
+	// ASSERT
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(calls) != 0 {
+		t.Errorf("wanted no calls, got %v", calls)
+	}
+}
+
+func TestThatBehaviorsReceiveTheDispatch(t *testing.T) {
+	testcases := []struct {
+		name   string
+		kind   Kind
+		act    func(m *Mediator) error
+		wanted reflect.Type
+	}{
+		{name: "handler", kind: HandlerKind, wanted: reflect.TypeOf(""), act: func(m *Mediator) error {
+			RegisterHandlerOn[string, string](m, &mockhandler[string, string]{execute: func(context.Context, string) (string, error) { return "", nil }})
+			_, err := PerformOn[string, string](m, context.Background(), "request")
+			return err
+		}},
+		{name: "receiver", kind: ReceiverKind, wanted: reflect.TypeOf(0), act: func(m *Mediator) error {
+			RegisterReceiverOn[int](m, &mockreceiver[int]{execute: func(context.Context, int) error { return nil }})
+			return SendOn(m, context.Background(), 42)
+		}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			var got Dispatch
+			m := New(WithBehaviors(BehaviorFunc(func(ctx context.Context, d Dispatch, next Next) (interface{}, error) {
+				got = d
+				return next(ctx)
+			})))
+
+			// ACT
+			err := tc.act(m)
+
+			// ASSERT
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Kind != tc.kind || got.Type != tc.wanted || got.Request == nil {
+				t.Errorf("wanted %v dispatch of %v, got %v dispatch of %v (request: %v)", tc.kind, tc.wanted, got.Kind, got.Type, got.Request)
+			}
+		})
+	}
+}
+
+func TestThatABehaviorCanShortCircuitTheHandler(t *testing.T) {
+	// ARRANGE
+
+	m := New(WithBehaviors(BehaviorFunc(func(context.Context, Dispatch, Next) (interface{}, error) {
+		return "cached", nil
+	})))
+	mock := &mockhandler[string, string]{execute: func(context.Context, string) (string, error) { return "result", nil }}
+	RegisterHandlerOn[string, string](m, mock)
+
+	// ACT
+
+	result, err := PerformOn[string, string](m, context.Background(), "request")
+
+	// ASSERT
+
+	if err != nil || result != "cached" {
+		t.Errorf("wanted %q, got %q (err: %v)", "cached", result, err)
+	}
+	if mock.WasCalled() {
+		t.Error("handler was called")
+	}
+}
+
+func TestThatAResultOfTheWrongTypeFromABehaviorIsNotReturned(t *testing.T) {
+	// ARRANGE
+
+	m := New(WithBehaviors(BehaviorFunc(func(context.Context, Dispatch, Next) (interface{}, error) {
+		return 42, nil
+	})))
+	RegisterHandlerOn[string, string](m, &mockhandler[string, string]{})
+
+	// ACT
+
+	result, err := PerformOn[string, string](m, context.Background(), "request")
+
+	// ASSERT
+
+	if err != nil || result != "" {
+		t.Errorf("wanted zero result, got %q (err: %v)", result, err)
+	}
+}
+
+func TestThatBehaviorsObserveValidationErrors(t *testing.T) {
+	// ARRANGE
+
+	var got error
+	m := New(WithBehaviors(BehaviorFunc(func(ctx context.Context, d Dispatch, next Next) (interface{}, error) {
+		result, err := next(ctx)
+		got = err
+		return result, err
+	})))
+	RegisterReceiverOn[string](m, &mockreceiver[string]{
+		execute:  func(context.Context, string) error { return nil },
+		validate: func(context.Context, string) error { return errors.New("invalid") },
+	})
+
+	// ACT
+
+	err := SendOn(m, context.Background(), "data")
+
+	// ASSERT
+
+	if !errors.As(got, &ValidationError{}) {
+		t.Errorf("wanted ValidationError, got %T", got)
+	}
+	if got != err {
+		t.Errorf("wanted %v returned, got %v", got, err)
+	}
+}
+
+func TestThatBehaviorsCanModifyTheContext(t *testing.T) {
+	// ARRANGE
+
+	type key struct{}
+	m := New(WithBehaviors(BehaviorFunc(func(ctx context.Context, d Dispatch, next Next) (interface{}, error) {
+		return next(context.WithValue(ctx, key{}, "value"))
+	})))
+
+	var got interface{}
+	RegisterReceiverOn[string](m, &mockreceiver[string]{execute: func(ctx context.Context, _ string) error {
+		got = ctx.Value(key{})
+		return nil
+	}})
+
+	// ACT
+
+	err := SendOn(m, context.Background(), "data")
+
+	// ASSERT
+
+	if err != nil || got != "value" {
+		t.Errorf("wanted %q, got %v (err: %v)", "value", got, err)
+	}
+}
+
+func TestKindString(t *testing.T) {
+	testcases := []struct {
+		kind   Kind
+		wanted string
+	}{
+		{HandlerKind, "handler"},
+		{ReceiverKind, "receiver"},
+		{SubscriberKind, "subscriber"},
+		{StreamHandlerKind, "stream handler"},
+		{Kind(0), "Kind(0)"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.wanted, func(t *testing.T) {
+			got := tc.kind.String()
+			if tc.wanted != got {
+				t.Errorf("wanted %q, got %q", tc.wanted, got)
+			}
+		})
+	}
+}
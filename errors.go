@@ -1,40 +1,109 @@
 package mediator
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"time"
 )
 
-// NoReceiverError is returned by Perform if there is no handler
+var (
+	// ErrNoHandler is matched (using errors.Is) by a NoHandlerError.
+	ErrNoHandler = errors.New("no handler")
+
+	// ErrNoReceiver is matched (using errors.Is) by a NoReceiverError.
+	ErrNoReceiver = errors.New("no receiver")
+
+	// ErrInvalidHandler is matched (using errors.Is) by an
+	// InvalidHandlerError.
+	ErrInvalidHandler = errors.New("invalid handler")
+
+	// ErrValidation is matched (using errors.Is) by a ValidationError.
+	//
+	// Any error matching ErrValidation, including an error wrapping a
+	// ValidationError, is a validation error: it is not retried (see
+	// IsRetryable) and is classified as such by OutcomeOf (used for
+	// metrics and logging) and by tracing.
+	ErrValidation = errors.New("validation error")
+
+	// ErrHandlerPanic is matched (using errors.Is) by a HandlerPanicError.
+	ErrHandlerPanic = errors.New("handler panic")
+)
+
+// NoHandlerError is returned by Perform if there is no handler
 // registered for the request type being performed.
 type NoHandlerError struct {
-	request interface{}
+	requesttype reflect.Type
 }
 
 func (e NoHandlerError) Error() string {
-	return fmt.Sprintf("no handler for '%T'", e.request)
+	return fmt.Sprintf("no handler for '%v'", e.requesttype)
+}
+
+// Is returns true if the target is ErrNoHandler.
+func (e NoHandlerError) Is(target error) bool {
+	return target == ErrNoHandler
+}
+
+// RequestType returns the request type for which there is no handler.
+func (e NoHandlerError) RequestType() reflect.Type {
+	return e.requesttype
 }
 
 // NoReceiverError is returned by Send if there is no receiver registered
 // for the data type being sent.
 type NoReceiverError struct {
-	data interface{}
+	datatype reflect.Type
 }
 
 func (e NoReceiverError) Error() string {
-	return fmt.Sprintf("no receiver for '%T'", e.data)
+	return fmt.Sprintf("no receiver for '%v'", e.datatype)
+}
+
+// Is returns true if the target is ErrNoReceiver.
+func (e NoReceiverError) Is(target error) bool {
+	return target == ErrNoReceiver
+}
+
+// DataType returns the data type for which there is no receiver.
+func (e NoReceiverError) DataType() reflect.Type {
+	return e.datatype
 }
 
 // InvalidHandlerError is returned by Perform if the registered
-// handler for the specified request type does not return then
+// handler for the specified request type does not return the
 // specified result type.
 type InvalidHandlerError struct {
-	handler interface{}
-	request interface{}
-	result  interface{}
+	handlertype reflect.Type
+	requesttype reflect.Type
+	resulttype  reflect.Type
 }
 
 func (e InvalidHandlerError) Error() string {
-	return fmt.Sprintf("handler for %T (%T) does not return %T", e.request, e.handler, e.result)
+	return fmt.Sprintf("handler for %v (%v) does not return %v", e.requesttype, e.handlertype, e.resulttype)
+}
+
+// Is returns true if the target is ErrInvalidHandler.
+func (e InvalidHandlerError) Is(target error) bool {
+	return target == ErrInvalidHandler
+}
+
+// HandlerType returns the type of the handler registered for the
+// request type.
+func (e InvalidHandlerError) HandlerType() reflect.Type {
+	return e.handlertype
+}
+
+// RequestType returns the request type.
+func (e InvalidHandlerError) RequestType() reflect.Type {
+	return e.requesttype
+}
+
+// ResultType returns the result type required (and not returned by
+// the handler).
+func (e InvalidHandlerError) ResultType() reflect.Type {
+	return e.resulttype
 }
 
 // ValidationError is returned by Perform or Send if the handler or
@@ -52,6 +121,175 @@ func (e ValidationError) Error() string {
 	return fmt.Sprintf("validation error: %v", e.error)
 }
 
+// Is returns true if the target is ErrValidation.
+func (e ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
 func (e ValidationError) Unwrap() error {
 	return e.error
 }
+
+// AmbiguousHandlerError is returned by Perform or Send when using
+// polymorphic dispatch, if there is no handler or receiver registered
+// for the request or data type and handlers or receivers registered for
+// more than one interface implemented by that type are equally applicable.
+type AmbiguousHandlerError struct {
+	requesttype reflect.Type
+	candidates  []reflect.Type
+}
+
+func (e AmbiguousHandlerError) Error() string {
+	return fmt.Sprintf("ambiguous handler for '%v': registered for %v", e.requesttype, e.candidates)
+}
+
+// RequestType returns the request (or data) type.
+func (e AmbiguousHandlerError) RequestType() reflect.Type {
+	return e.requesttype
+}
+
+// Candidates returns the (interface) types for which equally applicable
+// handlers or receivers are registered.
+func (e AmbiguousHandlerError) Candidates() []reflect.Type {
+	return append([]reflect.Type{}, e.candidates...)
+}
+
+// DuplicateRegistrationError is returned (or the panic value) when
+// registering a handler, receiver or stream handler for a type for
+// which an implementation is already registered.
+type DuplicateRegistrationError struct {
+	kind         Kind
+	requesttype  reflect.Type
+	existingtype reflect.Type
+}
+
+func (e DuplicateRegistrationError) Error() string {
+	return fmt.Sprintf("%v already registered for %v (%v)", e.kind, e.requesttype, e.existingtype)
+}
+
+// Kind returns the kind of the registration (handler, receiver or
+// stream handler).
+func (e DuplicateRegistrationError) Kind() Kind {
+	return e.kind
+}
+
+// RequestType returns the request (or data) type for which the
+// registration was attempted.
+func (e DuplicateRegistrationError) RequestType() reflect.Type {
+	return e.requesttype
+}
+
+// ExistingType returns the type of the implementation already
+// registered for the request (or data) type.
+func (e DuplicateRegistrationError) ExistingType() reflect.Type {
+	return e.existingtype
+}
+
+// HandlerPanicError is returned by Perform, Send, Publish (or from the
+// Err() of an ItemStream) if a validator, handler, receiver, subscriber
+// or stream handler panics and the Mediator is configured to recover
+// from panics (see WithPanicRecovery).
+type HandlerPanicError struct {
+	kind        Kind
+	requesttype reflect.Type
+	value       interface{}
+	stack       []byte
+}
+
+func (e HandlerPanicError) Error() string {
+	return fmt.Sprintf("panic in %v for '%v': %v", e.kind, e.requesttype, e.value)
+}
+
+// Is returns true if the target is ErrHandlerPanic.
+func (e HandlerPanicError) Is(target error) bool {
+	return target == ErrHandlerPanic
+}
+
+// Unwrap returns the recovered value if it is an error, otherwise nil.
+func (e HandlerPanicError) Unwrap() error {
+	err, _ := e.value.(error)
+	return err
+}
+
+// Kind returns the kind of implementation that panicked.
+func (e HandlerPanicError) Kind() Kind {
+	return e.kind
+}
+
+// RequestType returns the type of the request (or data, or notification)
+// being processed when the panic occurred.
+func (e HandlerPanicError) RequestType() reflect.Type {
+	return e.requesttype
+}
+
+// Value returns the value recovered from the panic.
+func (e HandlerPanicError) Value() interface{} {
+	return e.value
+}
+
+// Stack returns the stack trace captured when the panic was recovered.
+func (e HandlerPanicError) Stack() []byte {
+	return e.stack
+}
+
+// TimeoutError is returned by Perform, Send, Publish (or from the Err()
+// of an ItemStream) if the timeout applicable to a request is exceeded
+// (see WithDefaultTimeout and Timeout).  A TimeoutError wraps
+// context.DeadlineExceeded.
+//
+// A TimeoutError is not returned if a deadline set on the context of
+// the caller is exceeded; in that case the error returned by the handler
+// (or receiver etc) is returned.
+type TimeoutError struct {
+	kind        Kind
+	requesttype reflect.Type
+	timeout     time.Duration
+}
+
+func (e TimeoutError) Error() string {
+	return fmt.Sprintf("%v for '%v' timed out after %v", e.kind, e.requesttype, e.timeout)
+}
+
+// Unwrap returns context.DeadlineExceeded.
+func (e TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// Kind returns the kind of implementation that timed out.
+func (e TimeoutError) Kind() Kind {
+	return e.kind
+}
+
+// RequestType returns the type of the request (or data, or notification)
+// that timed out.
+func (e TimeoutError) RequestType() reflect.Type {
+	return e.requesttype
+}
+
+// Timeout returns the timeout that was exceeded.
+func (e TimeoutError) Timeout() time.Duration {
+	return e.timeout
+}
+
+// RetryError is returned if a handler (or receiver or subscriber)
+// registered with a retry policy returns an error after more than one
+// attempt.  It wraps the error returned by the final attempt or, if the
+// timeout for the request expired, a TimeoutError.
+type RetryError struct {
+	attempts int
+	err      error
+}
+
+func (e RetryError) Error() string {
+	return fmt.Sprintf("failed after %d attempts: %v", e.attempts, e.err)
+}
+
+// Unwrap returns the error returned by the final attempt.
+func (e RetryError) Unwrap() error {
+	return e.err
+}
+
+// Attempts returns the number of attempts made.
+func (e RetryError) Attempts() int {
+	return e.attempts
+}
@@ -3,66 +3,104 @@ package mediator
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 )
 
 func Test_NoHandlerError(t *testing.T) {
 
 	// ARRANGE
-	request := "request"
+	requesttype := reflect.TypeOf("request")
 
 	// ACT
 
-	err := &NoHandlerError{request: request}
+	err := NoHandlerError{requesttype: requesttype}
 
 	// ASSERT
 
-	wanted := fmt.Sprintf("no handler for '%T'", request)
+	wanted := "no handler for 'string'"
 	got := err.Error()
 	if got != wanted {
 		t.Errorf("wanted %q, got %q", wanted, got)
 	}
+
+	t.Run("request type", func(t *testing.T) {
+		if got := err.RequestType(); got != requesttype {
+			t.Errorf("wanted %v, got %v", requesttype, got)
+		}
+	})
+
+	t.Run("is ErrNoHandler", func(t *testing.T) {
+		if !errors.Is(err, ErrNoHandler) || !errors.Is(&err, ErrNoHandler) {
+			t.Error("wanted errors.Is(err, ErrNoHandler)")
+		}
+		if errors.Is(err, ErrNoReceiver) {
+			t.Error("did not want errors.Is(err, ErrNoReceiver)")
+		}
+	})
 }
 
 func Test_NoReceiverError(t *testing.T) {
 
 	// ARRANGE
-	data := "request"
+	datatype := reflect.TypeOf("request")
 
 	// ACT
 
-	err := &NoReceiverError{data: data}
+	err := NoReceiverError{datatype: datatype}
 
 	// ASSERT
 
-	wanted := fmt.Sprintf("no receiver for '%T'", data)
+	wanted := "no receiver for 'string'"
 	got := err.Error()
 	if got != wanted {
 		t.Errorf("wanted %q, got %q", wanted, got)
 	}
+
+	t.Run("data type", func(t *testing.T) {
+		if got := err.DataType(); got != datatype {
+			t.Errorf("wanted %v, got %v", datatype, got)
+		}
+	})
+
+	t.Run("is ErrNoReceiver", func(t *testing.T) {
+		if !errors.Is(fmt.Errorf("wrapped: %w", err), ErrNoReceiver) {
+			t.Error("wanted errors.Is(err, ErrNoReceiver)")
+		}
+	})
 }
 
 func Test_InvalidHandlerError(t *testing.T) {
 
 	// ARRANGE
 
-	request := "request"
-	handlerResult := "result"
-	improperResult := true
-	mock, reg := MockHandlerReturningValues[string](handlerResult, nil)
-	defer reg.Remove()
+	handlertype := reflect.TypeOf(&mockhandler[string, string]{})
+	requesttype := reflect.TypeOf("request")
+	resulttype := reflect.TypeOf(true)
 
 	// ACT
 
-	err := &InvalidHandlerError{handler: mock, request: request, result: true}
+	err := InvalidHandlerError{handlertype: handlertype, requesttype: requesttype, resulttype: resulttype}
 
 	// ASSERT
 
-	wanted := fmt.Sprintf("handler for %T (%T) does not return %T", request, mock, improperResult)
+	wanted := "handler for string (*mediator.mockhandler[string,string]) does not return bool"
 	got := err.Error()
 	if got != wanted {
 		t.Errorf("wanted %q, got %q", wanted, got)
 	}
+
+	t.Run("types", func(t *testing.T) {
+		if err.HandlerType() != handlertype || err.RequestType() != requesttype || err.ResultType() != resulttype {
+			t.Errorf("wanted %v, %v, %v, got %v, %v, %v", handlertype, requesttype, resulttype, err.HandlerType(), err.RequestType(), err.ResultType())
+		}
+	})
+
+	t.Run("is ErrInvalidHandler", func(t *testing.T) {
+		if !errors.Is(err, ErrInvalidHandler) {
+			t.Error("wanted errors.Is(err, ErrInvalidHandler)")
+		}
+	})
 }
 
 func Test_ValidationError(t *testing.T) {
@@ -91,4 +129,32 @@ func Test_ValidationError(t *testing.T) {
 			t.Errorf("wanted %q, got %q", wanted, got)
 		}
 	})
+
+	t.Run("is ErrValidation", func(t *testing.T) {
+		if !errors.Is(err, ErrValidation) || !errors.Is(err, inner) {
+			t.Error("wanted errors.Is(err, ErrValidation) and errors.Is(err, inner)")
+		}
+	})
+}
+
+func Test_DuplicateRegistrationError(t *testing.T) {
+
+	// ARRANGE
+
+	err := DuplicateRegistrationError{
+		kind:         ReceiverKind,
+		requesttype:  reflect.TypeOf(""),
+		existingtype: reflect.TypeOf(&mockreceiver[string]{}),
+	}
+
+	// ACT
+
+	got := err.Error()
+
+	// ASSERT
+
+	wanted := "receiver already registered for string (*mediator.mockreceiver[string])"
+	if got != wanted {
+		t.Errorf("wanted %q, got %q", wanted, got)
+	}
 }
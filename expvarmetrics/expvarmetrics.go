@@ -0,0 +1,130 @@
+// Package expvarmetrics provides a mediator.Metrics that publishes the
+// count, latency and outcomes of requests using the expvar package.
+package expvarmetrics
+
+import (
+	"context"
+	"expvar"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/blugnu/go-mediator"
+)
+
+// DefaultBuckets are the upper bounds of the latency buckets used if no
+// buckets are specified.
+var DefaultBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// Metrics is a mediator.Metrics that records measurements in an
+// expvar.Map, keyed by kind and request type, e.g.
+//
+//	"mediator": {
+//	    "handler main.GetProduct": {
+//	        "count": 3,
+//	        "outcomes": {"success": 2, "validation_error": 1},
+//	        "seconds": 0.0042,
+//	        "latency": {"le_0.001": 1, "le_0.005": 3, ..., "le_+Inf": 3}
+//	    }
+//	}
+//
+// Latency buckets are cumulative, as for a Prometheus histogram.
+type Metrics struct {
+	mu      sync.Mutex
+	vars    *expvar.Map
+	buckets []time.Duration
+}
+
+// New returns a Metrics publishing an expvar.Map with the specified name
+// and latency buckets (DefaultBuckets if none are specified).
+//
+// As for expvar.Publish, New panics if the name is already published.
+func New(name string, buckets ...time.Duration) *Metrics {
+	return NewWithMap(expvar.NewMap(name), buckets...)
+}
+
+// NewWithMap returns a Metrics recording in the specified expvar.Map with
+// the specified latency buckets (DefaultBuckets if none are specified).
+//
+// The map may be one that is already published (e.g. to share a map with
+// other metrics), or one that is not published at all (e.g. in tests).
+func NewWithMap(vars *expvar.Map, buckets ...time.Duration) *Metrics {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	return &Metrics{
+		vars:    vars,
+		buckets: buckets,
+	}
+}
+
+// Record records a measurement.
+func (m *Metrics) Record(_ context.Context, ms mediator.Measurement) {
+	v := m.entry(ms.Kind.String() + " " + typeName(ms.RequestType))
+
+	v.Add("count", 1)
+	v.Get("outcomes").(*expvar.Map).Add(ms.Outcome.String(), 1)
+	v.AddFloat("seconds", ms.Duration.Seconds())
+
+	latency := v.Get("latency").(*expvar.Map)
+	for _, b := range m.buckets {
+		if ms.Duration <= b {
+			latency.Add(bucket(b), 1)
+		}
+	}
+	latency.Add("le_+Inf", 1)
+}
+
+// entry returns the expvar.Map for the specified key, initialising it
+// if it does not already exist
+func (m *Metrics) entry(key string) *expvar.Map {
+	if v, ok := m.vars.Get(key).(*expvar.Map); ok {
+		return v
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if v, ok := m.vars.Get(key).(*expvar.Map); ok {
+		return v
+	}
+
+	latency := new(expvar.Map).Init()
+	for _, b := range m.buckets {
+		latency.Add(bucket(b), 0)
+	}
+	latency.Add("le_+Inf", 0)
+
+	v := new(expvar.Map).Init()
+	v.Add("count", 0)
+	v.Set("outcomes", new(expvar.Map).Init())
+	v.AddFloat("seconds", 0)
+	v.Set("latency", latency)
+	m.vars.Set(key, v)
+
+	return v
+}
+
+// bucket returns the key of the latency bucket with the specified
+// upper bound
+func bucket(d time.Duration) string {
+	return "le_" + strconv.FormatFloat(d.Seconds(), 'g', -1, 64)
+}
+
+// typeName returns the name of the specified type, or "<nil>" if nil
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return "<nil>"
+	}
+	return t.String()
+}
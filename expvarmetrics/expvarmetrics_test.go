@@ -0,0 +1,99 @@
+package expvarmetrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/blugnu/go-mediator"
+)
+
+func TestMetrics(t *testing.T) {
+	// ARRANGE
+	metrics := NewWithMap(new(expvar.Map), 10*time.Millisecond, time.Second)
+	ctx := context.Background()
+	m := mediator.New(mediator.WithMetrics(metrics))
+	mediator.HandleOn(m, func(_ context.Context, fail bool) (int, error) {
+		if fail {
+			return 0, errors.New("failed")
+		}
+		return 1, nil
+	})
+
+	// ACT
+	_, _ = mediator.PerformOn[bool, int](m, ctx, false)
+	_, _ = mediator.PerformOn[bool, int](m, ctx, true)
+	metrics.Record(ctx, mediator.Measurement{
+		Kind:        mediator.HandlerKind,
+		RequestType: reflect.TypeOf(true),
+		Outcome:     mediator.OutcomeSuccess,
+		Duration:    100 * time.Millisecond,
+	})
+
+	// ASSERT
+	got := struct {
+		Count    int
+		Outcomes map[string]int
+		Seconds  float64
+		Latency  map[string]int
+	}{}
+	if err := json.Unmarshal([]byte(metrics.vars.Get("handler bool").String()), &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Count != 3 {
+		t.Errorf("wanted count 3, got %d", got.Count)
+	}
+	if wanted := map[string]int{"success": 2, "execution_error": 1}; !reflect.DeepEqual(wanted, got.Outcomes) {
+		t.Errorf("wanted outcomes %v, got %v", wanted, got.Outcomes)
+	}
+	if got.Seconds < 0.1 {
+		t.Errorf("wanted at least 0.1 seconds, got %v", got.Seconds)
+	}
+	if wanted := map[string]int{"le_0.01": 2, "le_1": 3, "le_+Inf": 3}; !reflect.DeepEqual(wanted, got.Latency) {
+		t.Errorf("wanted latency %v, got %v", wanted, got.Latency)
+	}
+}
+
+func TestMetricsWithNilRequests(t *testing.T) {
+	// ARRANGE
+	metrics := NewWithMap(new(expvar.Map))
+	ctx := context.Background()
+	m := mediator.New(mediator.WithMetrics(metrics))
+
+	// ACT
+	_, perr := mediator.PerformOn[any, int](m, ctx, nil)
+	serr := mediator.SendOn[any](m, ctx, nil)
+	metrics.Record(ctx, mediator.Measurement{Kind: mediator.HandlerKind, Outcome: mediator.OutcomeNoHandler})
+
+	// ASSERT
+	if !errors.Is(perr, mediator.ErrNoHandler) || !errors.Is(serr, mediator.ErrNoReceiver) {
+		t.Errorf("wanted no handler and no receiver errors, got %v and %v", perr, serr)
+	}
+	for _, key := range []string{"handler interface {}", "receiver interface {}", "handler <nil>"} {
+		if metrics.vars.Get(key) == nil {
+			t.Errorf("wanted %q to be recorded", key)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	// ARRANGE
+	name := fmt.Sprintf("%s_%d", t.Name(), time.Now().UnixNano())
+
+	// ACT
+	metrics := New(name)
+
+	// ASSERT
+	if got := expvar.Get(name); got != metrics.vars {
+		t.Errorf("wanted %q to publish the metrics map, got %v", name, got)
+	}
+	if !reflect.DeepEqual(DefaultBuckets, metrics.buckets) {
+		t.Errorf("wanted default buckets, got %v", metrics.buckets)
+	}
+}
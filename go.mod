@@ -1,3 +1,3 @@
 module github.com/blugnu/go-mediator
 
-go 1.18
+go 1.21
@@ -0,0 +1,155 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFuncAdapters(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("HandlerFunc", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		RegisterHandlerOn[string, int](m, HandlerFunc[string, int](func(_ context.Context, s string) (int, error) {
+			return len(s), nil
+		}))
+
+		// ACT
+		result, err := PerformOn[string, int](m, ctx, "request")
+
+		// ASSERT
+		if err != nil || result != 7 {
+			t.Errorf("wanted 7, got %d (error %v)", result, err)
+		}
+	})
+
+	t.Run("ReceiverFunc", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		received := ""
+		RegisterReceiverOn[string](m, ReceiverFunc[string](func(_ context.Context, s string) error {
+			received = s
+			return nil
+		}))
+
+		// ACT
+		err := SendOn(m, ctx, "data")
+
+		// ASSERT
+		if err != nil || received != "data" {
+			t.Errorf("wanted %q, got %q (error %v)", "data", received, err)
+		}
+	})
+
+	t.Run("ValidatorFunc", func(t *testing.T) {
+		// ARRANGE
+		verr := errors.New("invalid")
+		var v Validator[string] = ValidatorFunc[string](func(context.Context, string) error { return verr })
+
+		// ACT
+		err := v.Validate(ctx, "input")
+
+		// ASSERT
+		if err != verr {
+			t.Errorf("wanted %v, got %v", verr, err)
+		}
+	})
+}
+
+func TestHandle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("infers request and result types", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+
+		// ACT
+		reg := HandleOn(m, func(_ context.Context, s string) (int, error) { return len(s), nil })
+
+		// ASSERT
+		if reg.Type() != reflect.TypeOf("") {
+			t.Errorf("wanted registration for string, got %v", reg.Type())
+		}
+		result, err := PerformOn[string, int](m, ctx, "request")
+		if err != nil || result != 7 {
+			t.Errorf("wanted 7, got %d (error %v)", result, err)
+		}
+	})
+
+	t.Run("with options", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		calls := []string{}
+		AddValidatorOn[string](m, ValidatorFunc[string](func(context.Context, string) error {
+			calls = append(calls, "mediator validator")
+			return nil
+		}))
+
+		// ACT
+		reg := HandleOn(m,
+			func(_ context.Context, s string) (int, error) {
+				calls = append(calls, "handler")
+				return 0, nil
+			},
+			Name("length"),
+			Validate(func(context.Context, string) error {
+				calls = append(calls, "validator")
+				return nil
+			}),
+			Behaviors(recorder("behavior", &calls)),
+		)
+		_, err := PerformOn[string, int](m, ctx, "request")
+
+		// ASSERT
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		wanted := []string{"behavior:before", "mediator validator", "validator", "handler", "behavior:after"}
+		if !reflect.DeepEqual(wanted, calls) {
+			t.Errorf("\nwanted %v\ngot    %v", wanted, calls)
+		}
+		if reg.Name() != "length" {
+			t.Errorf("wanted name %q, got %q", "length", reg.Name())
+		}
+		info := reg.Info()
+		if !info.Validator {
+			t.Error("wanted Validator to be true")
+		}
+		if wanted, got := `handler "length": string -> int (mediator.HandlerFunc[string,int], validator)`, info.String(); wanted != got {
+			t.Errorf("\nwanted %s\ngot    %s", wanted, got)
+		}
+	})
+
+	t.Run("validator returning an error", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		HandleOn(m, func(context.Context, string) (int, error) { return 0, nil },
+			Validate(func(context.Context, string) error { return errors.New("invalid") }),
+		)
+
+		// ACT
+		_, err := PerformOn[string, int](m, ctx, "request")
+
+		// ASSERT
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("wanted ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("validator for a different type", func(t *testing.T) {
+		// ARRANGE
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("did not panic")
+			}
+		}()
+
+		// ACT
+		HandleOn(New(), func(context.Context, string) (int, error) { return 0, nil },
+			Validate(func(context.Context, int) error { return nil }),
+		)
+	})
+}
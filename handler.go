@@ -2,62 +2,192 @@ package mediator
 
 import (
 	"context"
-	"fmt"
 	"reflect"
 )
 
 // RegisterHandler registers a handler for the specified request type
-// returning the specified result type.
+// returning the specified result type with the default Mediator.
+//
+// The request type may be an interface, in which case the handler may
+// be used for requests of any type implementing that interface when
+// using polymorphic dispatch (see WithPolymorphicDispatch).
 //
 // If a handler is already registered for the request type, the
-// function will panic, otherwise the handler is registered.
-func RegisterHandler[TRequest any, TResult any](handler Handler[TRequest, TResult]) *reg {
-	dummyrequest := *new(TRequest)
-	requesttype := reflect.TypeOf(dummyrequest)
+// function will panic with a DuplicateRegistrationError.  If the request
+// type declares a result type (see Request) other than the result type
+// of the handler, the function will panic with an InvalidHandlerError.
+// Otherwise the handler is registered.
+func RegisterHandler[TRequest any, TResult any](handler Handler[TRequest, TResult], opts ...RegistrationOption) *Registration {
+	return RegisterHandlerOn[TRequest, TResult](defaultMediator, handler, opts...)
+}
+
+// RegisterHandlerOn registers a handler for the specified request type
+// returning the specified result type with the specified Mediator.
+//
+// If a handler is already registered with the Mediator for the request
+// type, the function will panic with a DuplicateRegistrationError,
+// otherwise the handler is registered.
+func RegisterHandlerOn[TRequest any, TResult any](m *Mediator, handler Handler[TRequest, TResult], opts ...RegistrationOption) *Registration {
+	r, err := TryRegisterHandlerOn[TRequest, TResult](m, handler, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// TryRegisterHandler registers a handler for the specified request type
+// returning the specified result type with the default Mediator.
+//
+// If a handler is already registered for the request type, the handler
+// is not registered and a DuplicateRegistrationError is returned.  If
+// the request type declares a result type (see Request) other than the
+// result type of the handler, an InvalidHandlerError is returned.
+func TryRegisterHandler[TRequest any, TResult any](handler Handler[TRequest, TResult], opts ...RegistrationOption) (*Registration, error) {
+	return TryRegisterHandlerOn[TRequest, TResult](defaultMediator, handler, opts...)
+}
 
-	_, exists := handlers[requesttype]
-	if exists {
-		panic(fmt.Sprintf("handler already registered for %T", dummyrequest))
+// TryRegisterHandlerOn registers a handler for the specified request type
+// returning the specified result type with the specified Mediator.
+//
+// If a handler is already registered with the Mediator for the request
+// type, the handler is not registered and a DuplicateRegistrationError
+// is returned.
+func TryRegisterHandlerOn[TRequest any, TResult any](m *Mediator, handler Handler[TRequest, TResult], opts ...RegistrationOption) (*Registration, error) {
+	r := handlerReg(m, handler, opts)
+	if err := checkResult(r); err != nil {
+		return nil, err
+	}
+	if err := register(r); err != nil {
+		return nil, err
 	}
 
-	handlers[requesttype] = handler
+	return r, nil
+}
+
+// OverrideHandler registers a handler for the specified request type
+// returning the specified result type with the default Mediator,
+// overriding any handler already registered for the request type.
+//
+// The overridden handler is not removed; when the registration of the
+// overriding handler is removed, the overridden handler is restored.
+// Overrides may themselves be overridden.
+//
+// If no handler is registered for the request type, the handler is
+// registered as if by RegisterHandler.  As for RegisterHandler, the
+// function will panic with an InvalidHandlerError if the request type
+// declares a result type other than the result type of the handler.
+func OverrideHandler[TRequest any, TResult any](handler Handler[TRequest, TResult], opts ...RegistrationOption) *Registration {
+	return OverrideHandlerOn[TRequest, TResult](defaultMediator, handler, opts...)
+}
 
-	return &reg{
-		registry:       handlers,
-		registeredtype: requesttype,
+// OverrideHandlerOn registers a handler for the specified request type
+// returning the specified result type with the specified Mediator,
+// overriding any handler already registered for the request type.
+//
+// The overridden handler is restored when the registration of the
+// overriding handler is removed, as for OverrideHandler.
+func OverrideHandlerOn[TRequest any, TResult any](m *Mediator, handler Handler[TRequest, TResult], opts ...RegistrationOption) *Registration {
+	r := handlerReg(m, handler, opts)
+	if err := checkResult(r); err != nil {
+		panic(err)
 	}
+	m.handlers.append(r)
+
+	return r
 }
 
-// Perform sends the specified request and context to the registered handler
-// for the request type and returns the result and error from that handler.
+// Handle registers a func as the handler for the request type accepted
+// by the func, returning its result type, with the default Mediator.
+//
+// The request and result types are inferred from the func, which may be
+// registered together with a validator, a name and any behaviors using
+// the Validate, Name and Behaviors options:
+//
+//	mediator.Handle(func(ctx context.Context, rq GetProduct) (*Product, error) {
+//	    ...
+//	}, mediator.Name("getProduct"), mediator.Validate(validateGetProduct))
+//
+// As for RegisterHandler, the function will panic if a handler is
+// already registered for the request type.
+func Handle[TRequest any, TResult any](fn HandlerFunc[TRequest, TResult], opts ...RegistrationOption) *Registration {
+	return HandleOn(defaultMediator, fn, opts...)
+}
+
+// HandleOn registers a func as the handler for the request type accepted
+// by the func, returning its result type, with the specified Mediator, in
+// the same way as for Handle.
+func HandleOn[TRequest any, TResult any](m *Mediator, fn HandlerFunc[TRequest, TResult], opts ...RegistrationOption) *Registration {
+	return RegisterHandlerOn[TRequest, TResult](m, fn, opts...)
+}
+
+// handlerReg returns a registration of a handler with a Mediator
+func handlerReg[TRequest any, TResult any](m *Mediator, handler Handler[TRequest, TResult], opts []RegistrationOption) *Registration {
+	r := newReg(m.handlers, HandlerKind, typeOf[TRequest](), handler, opts)
+	r.resulttype = typeOf[TResult]()
+	r.validate = validatorFor[TRequest](handler)
+	r.preprocess = preprocessorFor[TRequest](handler)
+	r.postprocess = postprocessorFor[TRequest, TResult](handler)
+	r.execute = func(ctx context.Context, request interface{}) (interface{}, error) {
+		return handler.Execute(ctx, request.(TRequest))
+	}
+	return r
+}
+
+// Perform sends the specified request and context to the handler registered
+// with the default Mediator for the request type and returns the result and
+// error from that handler.
 //
 // If the handler implements Validator and the validator returns an error,
 // then handler is not called and the error returned by Perform will be a
 // ValidationError, wrapping the error returned by the validator.
+//
+// The request is passed through any behaviors added to the Mediator or
+// to the handler registration before being validated and executed.
 func Perform[TRequest any, TResult any](ctx context.Context, request TRequest) (TResult, error) {
-	requesttype := reflect.TypeOf(request)
+	return PerformOn[TRequest, TResult](defaultMediator, ctx, request)
+}
+
+// PerformOn sends the specified request and context to the handler registered
+// with the specified Mediator for the request type and returns the result and
+// error from that handler.
+//
+// The request is validated (if the handler implements Validator) in the
+// same way as for Perform.
+func PerformOn[TRequest any, TResult any](m *Mediator, ctx context.Context, request TRequest) (_ TResult, err error) {
+	var outcome Outcome
+	defer m.measure(ctx, HandlerKind, requestType(request))(&outcome, &err)
+
 	zeroresult := *new(TResult)
 
-	reg, ok := handlers[requesttype]
-	if !ok {
-		return zeroresult, &NoReceiverError{data: request}
+	reg, rq, err := m.resolve(m.handlers, request)
+	if err != nil {
+		outcome = OutcomeInvalidHandler
+		return zeroresult, err
 	}
-
-	handler, ok := reg.(Handler[TRequest, TResult])
-	if !ok {
-		return zeroresult, &InvalidHandlerError{handler: handler, request: request, result: zeroresult}
+	if reg == nil {
+		outcome = OutcomeNoHandler
+		m.observeNoHandler(ctx, HandlerKind, request)
+		return zeroresult, NoHandlerError{requesttype: reflect.TypeOf(request)}
 	}
 
-	// If the handler implements validator, call that first
-	// and return any error
-	if validator, ok := reg.(Validator[TRequest]); ok {
-		err := validate(validator, ctx, request)
-		if err != nil {
-			return zeroresult, err
+	if reg.resulttype != typeOf[TResult]() {
+		outcome = OutcomeInvalidHandler
+		return zeroresult, InvalidHandlerError{
+			handlertype: reflect.TypeOf(reg.implementation),
+			requesttype: reflect.TypeOf(request),
+			resulttype:  typeOf[TResult](),
 		}
 	}
 
-	response, err := handler.Execute(ctx, request)
+	d := Dispatch{Kind: HandlerKind, Type: reflect.TypeOf(request), Request: request}
+	result, err := m.dispatch(ctx, d, reg, func(ctx context.Context) (interface{}, error) {
+		return m.call(ctx, d, reg, rq)
+	})
 
+	response, ok := result.(TResult)
+	if !ok {
+		return zeroresult, err
+	}
 	return response, err
 }
@@ -1,24 +1,28 @@
 package mediator
 
-import "context"
+import (
+	"context"
+	"sync"
+)
 
 type mockhandler[TRequest any, TResult any] struct {
+	mu       sync.Mutex
 	requests []TRequest
 	validate func(context.Context, TRequest) error
 	execute  func(context.Context, TRequest) (TResult, error)
 }
 
-func MockHandler[TRequest any, TResult any]() (*mockhandler[TRequest, TResult], *reg) {
+func MockHandler[TRequest any, TResult any]() (*mockhandler[TRequest, TResult], *Registration) {
 	return MockHandlerReturningError[TRequest, TResult](nil)
 }
 
-func MockHandlerWithFunc[TRequest any, TResult any](cmd HandlerFunc[TRequest, TResult]) (*mockhandler[TRequest, TResult], *reg) {
+func MockHandlerWithFunc[TRequest any, TResult any](cmd HandlerFunc[TRequest, TResult]) (*mockhandler[TRequest, TResult], *Registration) {
 	h := &mockhandler[TRequest, TResult]{execute: cmd}
 	r := RegisterHandler[TRequest, TResult](h)
 	return h, r
 }
 
-func MockHandlerWithValidator[TRequest any, TResult any](qry HandlerFunc[TRequest, TResult], validator ValidatorFunc[TRequest]) (*mockhandler[TRequest, TResult], *reg) {
+func MockHandlerWithValidator[TRequest any, TResult any](qry HandlerFunc[TRequest, TResult], validator ValidatorFunc[TRequest]) (*mockhandler[TRequest, TResult], *Registration) {
 	h := &mockhandler[TRequest, TResult]{
 		execute:  qry,
 		validate: validator,
@@ -27,15 +31,15 @@ func MockHandlerWithValidator[TRequest any, TResult any](qry HandlerFunc[TReques
 	return h, r
 }
 
-func MockHandlerReturningError[TRequest any, TResult any](err error) (*mockhandler[TRequest, TResult], *reg) {
+func MockHandlerReturningError[TRequest any, TResult any](err error) (*mockhandler[TRequest, TResult], *Registration) {
 	return MockHandlerWithFunc(func(context.Context, TRequest) (TResult, error) { return *new(TResult), err })
 }
 
-func MockHandlerReturningValues[TRequest any, TResult any](result TResult, err error) (*mockhandler[TRequest, TResult], *reg) {
+func MockHandlerReturningValues[TRequest any, TResult any](result TResult, err error) (*mockhandler[TRequest, TResult], *Registration) {
 	return MockHandlerWithFunc(func(context.Context, TRequest) (TResult, error) { return result, err })
 }
 
-func MockHandlerWithValidatorError[TRequest any, TResult any](err error) (*mockhandler[TRequest, TResult], *reg) {
+func MockHandlerWithValidatorError[TRequest any, TResult any](err error) (*mockhandler[TRequest, TResult], *Registration) {
 	return MockHandlerWithValidator(
 		func(context.Context, TRequest) (TResult, error) { return *new(TResult), nil },
 		func(context.Context, TRequest) error { return err },
@@ -47,7 +51,10 @@ func (mock *mockhandler[TRequest, TResult]) Execute(ctx context.Context, request
 }
 
 func (mock *mockhandler[TRequest, TResult]) Validate(ctx context.Context, request TRequest) error {
+	mock.mu.Lock()
 	mock.requests = append(mock.requests, request)
+	mock.mu.Unlock()
+
 	if mock.validate != nil {
 		return mock.validate(ctx, request)
 	}
@@ -55,17 +62,25 @@ func (mock *mockhandler[TRequest, TResult]) Validate(ctx context.Context, reques
 }
 
 func (mock *mockhandler[TRequest, TResult]) NumRequests() int {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
 	return len(mock.requests)
 }
 
 func (mock *mockhandler[TRequest, TResult]) Requests() []TRequest {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
 	return append([]TRequest{}, mock.requests...)
 }
 
 func (mock *mockhandler[TRequest, TResult]) WasCalled() bool {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
 	return len(mock.requests) > 0
 }
 
 func (mock *mockhandler[TRequest, TResult]) WasNotCalled() bool {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
 	return len(mock.requests) == 0
 }
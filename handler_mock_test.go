@@ -8,7 +8,7 @@ import (
 
 func TestMockHandler(t *testing.T) {
 
-	if len(handlers) > 0 {
+	if defaultMediator.handlers.len() > 0 {
 		t.Fatal("invalid test: one or more handlers are already registered")
 	}
 
@@ -17,7 +17,7 @@ func TestMockHandler(t *testing.T) {
 
 	t.Run("registers the handler", func(t *testing.T) {
 		wanted := 1
-		got := len(handlers)
+		got := defaultMediator.handlers.len()
 		if wanted != got {
 			t.Errorf("wanted %d, got %d", wanted, got)
 		}
@@ -3,12 +3,13 @@ package mediator
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 )
 
 func TestThatTheRegistrationInterfaceRemovesTheHandlerHandler(t *testing.T) {
 
-	if len(handlers) > 0 {
+	if defaultMediator.handlers.len() > 0 {
 		t.Fatal("invalid test: one or more handlers are already registered")
 	}
 
@@ -19,7 +20,7 @@ func TestThatTheRegistrationInterfaceRemovesTheHandlerHandler(t *testing.T) {
 	// ACT
 
 	wanted := 1
-	got := len(handlers)
+	got := defaultMediator.handlers.len()
 	if wanted != got {
 		t.Errorf("wanted %d handlers, got %d", wanted, got)
 	}
@@ -28,7 +29,7 @@ func TestThatTheRegistrationInterfaceRemovesTheHandlerHandler(t *testing.T) {
 	// ASSERT
 
 	wanted = 0
-	got = len(handlers)
+	got = defaultMediator.handlers.len()
 	if wanted != got {
 		t.Errorf("wanted %d handlers, got %d", wanted, got)
 	}
@@ -65,8 +66,11 @@ func TestThatHandlerReturnsExpectedErrorWhenHandlerIsNotRegistered(t *testing.T)
 
 	// ASSERT
 
-	if _, ok := err.(*NoReceiverError); !ok {
-		t.Errorf("wanted *mediator.ErrNoHandler, got %T", err)
+	if _, ok := err.(NoHandlerError); !ok {
+		t.Errorf("wanted mediator.NoHandlerError, got %T", err)
+	}
+	if !errors.Is(err, ErrNoHandler) {
+		t.Errorf("wanted mediator.ErrNoHandler, got %v", err)
 	}
 }
 
@@ -84,8 +88,11 @@ func TestThatHandlerReturnsExpectedErrorWhenHandlerResultIsWrongType(t *testing.
 
 	// ASSERT
 
-	if _, ok := err.(*InvalidHandlerError); !ok {
-		t.Errorf("wanted *mediator.ErrInvalidHandler, got %T", err)
+	if _, ok := err.(InvalidHandlerError); !ok {
+		t.Errorf("wanted mediator.InvalidHandlerError, got %T", err)
+	}
+	if !errors.Is(err, ErrInvalidHandler) {
+		t.Errorf("wanted mediator.ErrInvalidHandler, got %v", err)
 	}
 }
 
@@ -152,3 +159,56 @@ func TestThatHandlerResultIsReturnedToCaller(t *testing.T) {
 		t.Errorf("wanted %q, got %q", wanted, got)
 	}
 }
+
+func TestTryRegisterHandler(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	existing := returning[string]("existing")
+	RegisterHandlerOn[string, string](m, existing)
+
+	// ACT
+
+	reg, err := TryRegisterHandlerOn[string, string](m, returning[string]("other"))
+
+	// ASSERT
+
+	if reg != nil {
+		t.Error("wanted nil registration")
+	}
+
+	wanted := DuplicateRegistrationError{}
+	if !errors.As(err, &wanted) {
+		t.Fatalf("wanted %T, got %T", wanted, err)
+	}
+	if wanted.Kind() != HandlerKind || wanted.RequestType() != reflect.TypeOf("") || wanted.ExistingType() != reflect.TypeOf(existing) {
+		t.Errorf("wanted %v for %v (existing %v), got %v for %v (existing %v)",
+			HandlerKind, reflect.TypeOf(""), reflect.TypeOf(existing),
+			wanted.Kind(), wanted.RequestType(), wanted.ExistingType())
+	}
+
+	t.Run("registers when no handler is registered", func(t *testing.T) {
+		reg, err := TryRegisterHandlerOn[int, string](m, returning[int]("int"))
+		if err != nil || reg == nil {
+			t.Errorf("wanted registration, got %v (err: %v)", reg, err)
+		}
+	})
+}
+
+func TestThatRegisterHandlerPanicsWithDuplicateRegistrationError(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	RegisterHandlerOn[string, string](m, returning[string]("existing"))
+
+	defer func() {
+		r := recover()
+		if _, ok := r.(DuplicateRegistrationError); !ok {
+			t.Errorf("wanted panic with DuplicateRegistrationError, got %T (%[1]v)", r)
+		}
+	}()
+
+	// ACT
+
+	RegisterHandlerOn[string, string](m, returning[string]("other"))
+}
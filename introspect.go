@@ -0,0 +1,147 @@
+package mediator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// RegistrationInfo describes a registration with a Mediator.
+type RegistrationInfo struct {
+	// Kind is the kind of implementation registered
+	Kind Kind
+
+	// Name is the name of the registration, if named (see Name)
+	Name string
+
+	// RequestType is the request, data or notification type for which
+	// the implementation is registered
+	RequestType reflect.Type
+
+	// ResultType is the result type of a handler or the item type of a
+	// stream handler; for receivers and subscribers it is nil
+	ResultType reflect.Type
+
+	// Implementation is the type of the registered implementation
+	Implementation reflect.Type
+
+	// Validator is true if the implementation implements Validator or
+	// validators were added to the registration (see Validate)
+	Validator bool
+}
+
+// String returns a description of the registration, e.g.
+//
+//	handler: main.GetProduct -> *main.Product (*main.GetProductHandler, validator)
+//
+// or, for a named registration:
+//
+//	handler "getProduct": main.GetProduct -> *main.Product (mediator.HandlerFunc[...], validator)
+func (info RegistrationInfo) String() string {
+	kind := info.Kind.String()
+	if info.Name != "" {
+		kind = fmt.Sprintf("%v %q", info.Kind, info.Name)
+	}
+	result := ""
+	if info.ResultType != nil {
+		result = fmt.Sprintf(" -> %v", info.ResultType)
+	}
+	validator := ""
+	if info.Validator {
+		validator = ", validator"
+	}
+	return fmt.Sprintf("%s: %v%s (%v%s)", kind, info.RequestType, result, info.Implementation, validator)
+}
+
+// Info returns a description of the registration.
+func (r *Registration) Info() RegistrationInfo {
+	return RegistrationInfo{
+		Kind:           r.kind,
+		Name:           r.name,
+		RequestType:    r.registeredtype,
+		ResultType:     r.resulttype,
+		Implementation: reflect.TypeOf(r.implementation),
+		Validator:      r.validate != nil || len(r.validators) > 0,
+	}
+}
+
+// Handlers returns a description of the handlers registered with the
+// default Mediator.
+func Handlers() []RegistrationInfo {
+	return defaultMediator.Handlers()
+}
+
+// Receivers returns a description of the receivers registered with the
+// default Mediator.
+func Receivers() []RegistrationInfo {
+	return defaultMediator.Receivers()
+}
+
+// Registrations returns a description of all handlers, receivers,
+// subscribers, stream handlers, validators and pre- and post-processors
+// registered with the default Mediator.
+func Registrations() []RegistrationInfo {
+	return defaultMediator.Registrations()
+}
+
+// Handlers returns a description of the handler registered with the
+// Mediator for each request type, sorted by request type.
+//
+// Where a handler has been overridden, only the overriding handler
+// (i.e. the handler used for the request type) is described.
+func (m *Mediator) Handlers() []RegistrationInfo {
+	return describe(m.handlers, false)
+}
+
+// Receivers returns a description of the receiver registered with the
+// Mediator for each data type, sorted by data type.
+//
+// Where a receiver has been overridden, only the overriding receiver
+// (i.e. the receiver used for the data type) is described.
+func (m *Mediator) Receivers() []RegistrationInfo {
+	return describe(m.receivers, false)
+}
+
+// Registrations returns a description of all handlers, receivers,
+// subscribers, stream handlers, validators and pre- and post-processors
+// registered with the Mediator, in that order and then sorted by request
+// type.
+//
+// As for Handlers and Receivers, overridden handlers and receivers
+// are not described.  All subscribers, validators and processors for each
+// type are described, in the order in which they were added.
+func (m *Mediator) Registrations() []RegistrationInfo {
+	result := describe(m.handlers, false)
+	result = append(result, describe(m.receivers, false)...)
+	result = append(result, describe(m.subscribers, true)...)
+	result = append(result, describe(m.streams, false)...)
+	result = append(result, describe(m.validators, true)...)
+	result = append(result, describe(m.preprocessors, true)...)
+	result = append(result, describe(m.postprocessors, true)...)
+	return result
+}
+
+// describe returns a description of the registrations in a registry,
+// sorted by registered type.  If all is false only the most recent
+// registration for each type is described.
+func describe(r *registry, all bool) []RegistrationInfo {
+	entries := r.entries()
+
+	types := make([]reflect.Type, 0, len(entries))
+	for t := range entries {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].String() < types[j].String() })
+
+	result := make([]RegistrationInfo, 0, len(types))
+	for _, t := range types {
+		regs := entries[t]
+		if !all {
+			regs = regs[len(regs)-1:]
+		}
+		for _, rg := range regs {
+			result = append(result, rg.Info())
+		}
+	}
+	return result
+}
@@ -0,0 +1,97 @@
+package mediator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestRegistrations(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	validating := returning[int]("int")
+	validating.validate = func(context.Context, int) error { return nil }
+
+	RegisterHandlerOn[string, string](m, returning[string]("string"))
+	RegisterHandlerOn[int, string](m, validating)
+	OverrideHandlerOn[int, string](m, returning[int]("override"))
+	RegisterReceiverOn[string](m, &mockreceiver[string]{})
+	SubscribeOn[notification](m, &mockreceiver[notification]{})
+	SubscribeOn[notification](m, &mockreceiver[notification]{})
+	RegisterStreamHandlerOn[pageRequest, int](m, pager())
+
+	handler := reflect.TypeOf(&mockhandler[string, string]{})
+	intHandler := reflect.TypeOf(&mockhandler[int, string]{})
+	receiver := reflect.TypeOf(&mockreceiver[string]{})
+	subscriber := reflect.TypeOf(&mockreceiver[notification]{})
+	stream := reflect.TypeOf(&streamhandler[pageRequest, int]{})
+	str := reflect.TypeOf("")
+	num := reflect.TypeOf(0)
+
+	t.Run("handlers", func(t *testing.T) {
+		wanted := []RegistrationInfo{
+			// the mock handler implements Validator regardless
+			{Kind: HandlerKind, RequestType: num, ResultType: str, Implementation: intHandler, Validator: true},
+			{Kind: HandlerKind, RequestType: str, ResultType: str, Implementation: handler, Validator: true},
+		}
+		got := m.Handlers()
+		if !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted %v\ngot    %v", wanted, got)
+		}
+	})
+
+	t.Run("receivers", func(t *testing.T) {
+		wanted := []RegistrationInfo{
+			{Kind: ReceiverKind, RequestType: str, Implementation: receiver, Validator: true},
+		}
+		got := m.Receivers()
+		if !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted %v\ngot    %v", wanted, got)
+		}
+	})
+
+	t.Run("all registrations", func(t *testing.T) {
+		wanted := []Kind{HandlerKind, HandlerKind, ReceiverKind, SubscriberKind, SubscriberKind, StreamHandlerKind}
+		got := []Kind{}
+		for _, info := range m.Registrations() {
+			got = append(got, info.Kind)
+		}
+		if !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted %v\ngot    %v", wanted, got)
+		}
+
+		last := m.Registrations()[5]
+		if last.Implementation != stream || last.ResultType != num || last.RequestType != reflect.TypeOf(pageRequest{}) {
+			t.Errorf("wanted stream handler yielding %v, got %v", num, last)
+		}
+		if m.Registrations()[3].Implementation != subscriber {
+			t.Errorf("wanted subscriber %v, got %v", subscriber, m.Registrations()[3])
+		}
+	})
+}
+
+func TestRegistrationInfoString(t *testing.T) {
+	testcases := []struct {
+		name   string
+		info   RegistrationInfo
+		wanted string
+	}{
+		{name: "handler",
+			info:   RegistrationInfo{Kind: HandlerKind, RequestType: reflect.TypeOf(""), ResultType: reflect.TypeOf(0), Implementation: reflect.TypeOf(&mockhandler[string, int]{}), Validator: true},
+			wanted: "handler: string -> int (*mediator.mockhandler[string,int], validator)",
+		},
+		{name: "receiver",
+			info:   RegistrationInfo{Kind: ReceiverKind, RequestType: reflect.TypeOf(""), Implementation: reflect.TypeOf(circle{})},
+			wanted: "receiver: string (mediator.circle)",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.info.String()
+			if tc.wanted != got {
+				t.Errorf("wanted %q, got %q", tc.wanted, got)
+			}
+		})
+	}
+}
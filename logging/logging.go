@@ -0,0 +1,300 @@
+// Package logging provides a mediator Behavior that logs the dispatch of
+// requests, data and notifications using log/slog.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/blugnu/go-mediator"
+)
+
+// Redacted is the value logged in place of a sensitive field.
+const Redacted = "[REDACTED]"
+
+// Option configures the Behavior.
+type Option func(*config)
+
+type config struct {
+	logger   *slog.Logger
+	levels   map[mediator.Outcome]slog.Level
+	sampling map[reflect.Type]uint64
+	redact   func(interface{}) slog.Value
+}
+
+// WithLogger specifies the logger used.  If not specified, the default
+// slog logger (at the time of each dispatch) is used.
+func WithLogger(logger *slog.Logger) Option {
+	return func(cfg *config) {
+		cfg.logger = logger
+	}
+}
+
+// WithLevel specifies the level at which dispatches with the specified
+// outcome are logged.  By default, successful dispatches are logged at
+// Info, validation errors at Warn and all other errors at Error.
+func WithLevel(outcome mediator.Outcome, level slog.Level) Option {
+	return func(cfg *config) {
+		cfg.levels[outcome] = level
+	}
+}
+
+// Sample specifies that only one in every n successful dispatches of
+// requests (or data, or notifications) of the specified type are to be
+// logged.  Dispatches returning an error are always logged.
+func Sample[TRequest any](n uint64) Option {
+	return func(cfg *config) {
+		cfg.sampling[reflect.TypeOf((*TRequest)(nil)).Elem()] = n
+	}
+}
+
+// WithRedactor specifies a func returning the value to be logged for a
+// request, replacing the default (see Redact).
+func WithRedactor(redact func(request interface{}) slog.Value) Option {
+	return func(cfg *config) {
+		cfg.redact = redact
+	}
+}
+
+// Behavior returns a mediator.Behavior that logs each request (or data,
+// or notification) dispatched, with the kind, request type, duration,
+// outcome, request and error (if any):
+//
+//	mediator.Use(logging.Behavior(logging.Sample[HealthCheck](100)))
+//
+// The request is logged as the value returned by the redactor (Redact,
+// by default) so that sensitive fields are never emitted.
+func Behavior(opts ...Option) mediator.Behavior {
+	cfg := &config{
+		levels: map[mediator.Outcome]slog.Level{
+			mediator.OutcomeSuccess:         slog.LevelInfo,
+			mediator.OutcomeValidationError: slog.LevelWarn,
+		},
+		sampling: map[reflect.Type]uint64{},
+		redact:   Redact,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	counters := sync.Map{} // reflect.Type -> *uint64
+
+	// sampled returns true if a successful dispatch of a request of the
+	// specified type is to be logged
+	sampled := func(t reflect.Type) bool {
+		n := cfg.sampling[t]
+		if n <= 1 {
+			return true
+		}
+		c, _ := counters.LoadOrStore(t, new(uint64))
+		return (atomic.AddUint64(c.(*uint64), 1)-1)%n == 0
+	}
+
+	return mediator.BehaviorFunc(func(ctx context.Context, d mediator.Dispatch, next mediator.Next) (interface{}, error) {
+		start := time.Now()
+		result, err := next(ctx)
+		duration := time.Since(start)
+
+		outcome := mediator.OutcomeOf(err)
+		level, ok := cfg.levels[outcome]
+		if !ok {
+			level = slog.LevelError
+		}
+
+		logger := cfg.logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		if !logger.Enabled(ctx, level) || (err == nil && !sampled(d.Type)) {
+			return result, err
+		}
+
+		attrs := []slog.Attr{
+			slog.String("kind", d.Kind.String()),
+			slog.String("request_type", d.Type.String()),
+			slog.Duration("duration", duration),
+			slog.String("outcome", outcome.String()),
+			slog.Attr{Key: "request", Value: cfg.redact(d.Request)},
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+		logger.LogAttrs(ctx, level, "mediator dispatch", attrs...)
+
+		return result, err
+	})
+}
+
+// Redact returns a slog.Value for a request in which any struct field
+// tagged as sensitive is replaced by Redacted:
+//
+//	type Login struct {
+//	    Username string
+//	    Password string `mediator:"sensitive"`
+//	}
+//
+// A value of a type that has no field tagged as sensitive, exported or
+// not (directly or in a nested struct, slice, array or map) is logged
+// as-is.  Otherwise, a struct (or pointer to a struct) is logged as a
+// group of its exported fields only, redacted recursively.  A request implementing slog.LogValuer is
+// logged as the value it returns.
+func Redact(request interface{}) slog.Value {
+	return redact(reflect.ValueOf(request))
+}
+
+// redact returns a slog.Value for a value, redacting any sensitive fields
+func redact(v reflect.Value) slog.Value {
+	if !v.IsValid() {
+		return slog.AnyValue(nil)
+	}
+	if v.CanInterface() {
+		if lv, ok := v.Interface().(slog.LogValuer); ok {
+			return lv.LogValue()
+		}
+		if !sensitive(v.Type()) {
+			return slog.AnyValue(v.Interface())
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return slog.AnyValue(nil)
+		}
+		return redact(v.Elem())
+
+	case reflect.Struct:
+		t := v.Type()
+		attrs := make([]slog.Attr, 0, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			if f.Tag.Get("mediator") == "sensitive" {
+				attrs = append(attrs, slog.String(f.Name, Redacted))
+				continue
+			}
+			attrs = append(attrs, slog.Attr{Key: f.Name, Value: redact(v.Field(i))})
+		}
+		return slog.GroupValue(attrs...)
+
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return slog.AnyValue(plain(v))
+	}
+
+	return slog.AnyValue(v.Interface())
+}
+
+// plain returns a value with any sensitive fields redacted, representing
+// structs as maps of their exported fields, for values in slices, arrays
+// and maps (which cannot be represented as slog groups).  Map keys are
+// formatted as strings after redaction.
+func plain(v reflect.Value) interface{} {
+	if !sensitive(v.Type()) {
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return plain(v.Elem())
+
+	case reflect.Struct:
+		t := v.Type()
+		result := make(map[string]interface{}, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			switch {
+			case !f.IsExported():
+				continue
+			case f.Tag.Get("mediator") == "sensitive":
+				result[f.Name] = Redacted
+			default:
+				result[f.Name] = plain(v.Field(i))
+			}
+		}
+		return result
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		if k := v.Type().Elem().Kind(); k <= reflect.Complex128 || k == reflect.String {
+			return v.Interface()
+		}
+		result := make([]interface{}, v.Len())
+		for i := range result {
+			result[i] = plain(v.Index(i))
+		}
+		return result
+
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		result := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			result[fmt.Sprint(plain(iter.Key()))] = plain(iter.Value())
+		}
+		return result
+	}
+
+	return v.Interface()
+}
+
+// sensitivity caches whether a type may contain sensitive fields
+var sensitivity sync.Map
+
+// sensitive returns true if values of the specified type may contain a
+// field tagged as sensitive, either directly or nested in a struct,
+// pointer, slice, array or map (as a key or value).  Interface types may
+// hold any value, so are always considered to be potentially sensitive.
+//
+// Unexported fields are considered, since a value logged as-is may be
+// formatted including its unexported fields.
+func sensitive(t reflect.Type) bool {
+	if s, ok := sensitivity.Load(t); ok {
+		return s.(bool)
+	}
+	s := containsSensitive(t, map[reflect.Type]bool{})
+	sensitivity.Store(t, s)
+	return s
+}
+
+// containsSensitive returns true if the specified type contains a field
+// tagged as sensitive, ignoring types already seen (recursive types)
+func containsSensitive(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return containsSensitive(t.Elem(), seen)
+
+	case reflect.Map:
+		return containsSensitive(t.Key(), seen) || containsSensitive(t.Elem(), seen)
+
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.Tag.Get("mediator") == "sensitive" || containsSensitive(f.Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
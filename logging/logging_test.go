@@ -0,0 +1,297 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blugnu/go-mediator"
+)
+
+type login struct {
+	Username string
+	Password string `mediator:"sensitive"`
+	Devices  []device
+	internal string
+}
+
+type device struct {
+	Name  string
+	Token string `mediator:"sensitive"`
+}
+
+type ping struct{}
+
+type credentials struct {
+	User     string
+	password string `mediator:"sensitive"`
+}
+
+type cred struct {
+	Secret string `mediator:"sensitive"`
+}
+
+type keyed struct {
+	Creds map[cred]int
+}
+
+type order struct {
+	ID     int
+	Placed time.Time
+	Secret string `mediator:"sensitive"`
+	Lines  []line
+}
+
+type line struct {
+	Placed time.Time
+	Amount *big.Int
+}
+
+// capture returns a logger writing JSON to a buffer and a func returning
+// the records logged
+func capture() (*slog.Logger, func() []map[string]interface{}) {
+	buf := &bytes.Buffer{}
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return logger, func() []map[string]interface{} {
+		records := []map[string]interface{}{}
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			record := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(line), &record); err != nil {
+				panic(err)
+			}
+			records = append(records, record)
+		}
+		return records
+	}
+}
+
+func TestBehavior(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("logs dispatch", func(t *testing.T) {
+		// ARRANGE
+		logger, records := capture()
+		m := mediator.New(mediator.WithBehaviors(Behavior(WithLogger(logger))))
+		mediator.RegisterReceiverOn[login](m, mediator.ReceiverFunc[login](func(context.Context, login) error { return nil }))
+
+		// ACT
+		_ = mediator.SendOn(m, ctx, login{
+			Username: "user",
+			Password: "secret",
+			Devices:  []device{{Name: "phone", Token: "secret"}},
+			internal: "secret",
+		})
+
+		// ASSERT
+		logged := records()
+		if len(logged) != 1 {
+			t.Fatalf("wanted 1 record, got %d", len(logged))
+		}
+		record := logged[0]
+		if record["level"] != "INFO" || record["kind"] != "receiver" || record["request_type"] != "logging.login" || record["outcome"] != "success" {
+			t.Errorf("unexpected record: %v", record)
+		}
+		if _, ok := record["duration"]; !ok {
+			t.Error("wanted duration to be logged")
+		}
+		wanted := map[string]interface{}{
+			"Username": "user",
+			"Password": Redacted,
+			"Devices":  []interface{}{map[string]interface{}{"Name": "phone", "Token": Redacted}},
+		}
+		if got := record["request"]; !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted request %v\ngot            %v", wanted, got)
+		}
+	})
+
+	t.Run("preserves values that are not sensitive", func(t *testing.T) {
+		// ARRANGE
+		logger, records := capture()
+		m := mediator.New(mediator.WithBehaviors(Behavior(WithLogger(logger))))
+		mediator.RegisterReceiverOn[order](m, mediator.ReceiverFunc[order](func(context.Context, order) error { return nil }))
+		mediator.RegisterReceiverOn[line](m, mediator.ReceiverFunc[line](func(context.Context, line) error { return nil }))
+		placed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		// ACT
+		_ = mediator.SendOn(m, ctx, order{
+			ID:     1,
+			Placed: placed,
+			Secret: "secret",
+			Lines:  []line{{Placed: placed, Amount: big.NewInt(42)}},
+		})
+		_ = mediator.SendOn(m, ctx, line{Placed: placed, Amount: big.NewInt(42)})
+
+		// ASSERT
+		logged := records()
+		if len(logged) != 2 {
+			t.Fatalf("wanted 2 records, got %d", len(logged))
+		}
+		wanted := map[string]interface{}{
+			"ID":     float64(1),
+			"Placed": "2024-01-02T03:04:05Z",
+			"Secret": Redacted,
+			"Lines":  []interface{}{map[string]interface{}{"Placed": "2024-01-02T03:04:05Z", "Amount": float64(42)}},
+		}
+		if got := logged[0]["request"]; !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted request %v\ngot            %v", wanted, got)
+		}
+		wantedLine := map[string]interface{}{"Placed": "2024-01-02T03:04:05Z", "Amount": float64(42)}
+		if got := logged[1]["request"]; !reflect.DeepEqual(wantedLine, got) {
+			t.Errorf("\nwanted request %v\ngot            %v", wantedLine, got)
+		}
+	})
+
+	t.Run("redacts unexported fields", func(t *testing.T) {
+		// ARRANGE
+		buf := &bytes.Buffer{}
+		logger := slog.New(slog.NewTextHandler(buf, nil))
+		m := mediator.New(mediator.WithBehaviors(Behavior(WithLogger(logger))))
+		mediator.RegisterReceiverOn[credentials](m, mediator.ReceiverFunc[credentials](func(context.Context, credentials) error { return nil }))
+
+		// ACT
+		_ = mediator.SendOn(m, ctx, credentials{User: "bob", password: "hunter2"})
+
+		// ASSERT
+		got := buf.String()
+		if strings.Contains(got, "hunter2") || !strings.Contains(got, "request.User=bob") {
+			t.Errorf("wanted User logged and password omitted, got %q", got)
+		}
+	})
+
+	t.Run("redacts map keys", func(t *testing.T) {
+		// ARRANGE
+		logger, records := capture()
+		m := mediator.New(mediator.WithBehaviors(Behavior(WithLogger(logger))))
+		mediator.RegisterReceiverOn[keyed](m, mediator.ReceiverFunc[keyed](func(context.Context, keyed) error { return nil }))
+
+		// ACT
+		_ = mediator.SendOn(m, ctx, keyed{Creds: map[cred]int{{Secret: "s3cret"}: 1}})
+
+		// ASSERT
+		wanted := map[string]interface{}{"Creds": map[string]interface{}{"map[Secret:[REDACTED]]": float64(1)}}
+		if got := records()[0]["request"]; !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted request %v\ngot            %v", wanted, got)
+		}
+	})
+
+	t.Run("levels", func(t *testing.T) {
+		// ARRANGE
+		logger, records := capture()
+		m := mediator.New(mediator.WithBehaviors(Behavior(
+			WithLogger(logger),
+			WithLevel(mediator.OutcomeSuccess, slog.LevelDebug),
+		)))
+		mediator.HandleOn(m, func(_ context.Context, rq string) (int, error) {
+			switch rq {
+			case "invalid":
+				return 0, mediator.ValidationError{}
+			case "error":
+				return 0, errors.New("failed")
+			}
+			return 0, nil
+		})
+
+		// ACT
+		for _, rq := range []string{"ok", "invalid", "error"} {
+			_, _ = mediator.PerformOn[string, int](m, ctx, rq)
+		}
+
+		// ASSERT
+		got := []interface{}{}
+		for _, record := range records() {
+			got = append(got, record["level"])
+		}
+		wanted := []interface{}{"DEBUG", "WARN", "ERROR"}
+		if !reflect.DeepEqual(wanted, got) {
+			t.Errorf("wanted levels %v, got %v", wanted, got)
+		}
+	})
+
+	t.Run("nested requests", func(t *testing.T) {
+		// ARRANGE
+		logger, records := capture()
+		m := mediator.New(mediator.WithBehaviors(Behavior(WithLogger(logger))))
+		mediator.HandleOn(m, func(ctx context.Context, rq string) (int, error) {
+			// sends data for which there is no receiver
+			return 0, mediator.SendOn(m, ctx, true)
+		})
+		mediator.RegisterReceiverOn[int](m, mediator.ReceiverFunc[int](func(context.Context, int) error {
+			return mediator.ValidationError{}
+		}))
+		mediator.HandleOn(m, func(ctx context.Context, rq bool) (int, error) {
+			return 0, fmt.Errorf("performing nested request: %w", mediator.SendOn(m, ctx, 42))
+		})
+
+		// ACT
+		_, _ = mediator.PerformOn[string, int](m, ctx, "request")
+		_, _ = mediator.PerformOn[bool, int](m, ctx, true)
+
+		// ASSERT
+		got := []interface{}{}
+		for _, record := range records() {
+			got = append(got, record["kind"], record["request_type"], record["outcome"])
+		}
+		wanted := []interface{}{
+			"handler", "string", "execution_error",
+			"receiver", "int", "validation_error",
+			"handler", "bool", "validation_error",
+		}
+		if !reflect.DeepEqual(wanted, got) {
+			t.Errorf("wanted %v, got %v", wanted, got)
+		}
+	})
+
+	t.Run("sampling", func(t *testing.T) {
+		// ARRANGE
+		logger, records := capture()
+		m := mediator.New(mediator.WithBehaviors(Behavior(WithLogger(logger), Sample[ping](3))))
+		failing := false
+		mediator.RegisterReceiverOn[ping](m, mediator.ReceiverFunc[ping](func(context.Context, ping) error {
+			if failing {
+				return errors.New("failed")
+			}
+			return nil
+		}))
+
+		// ACT
+		for i := 0; i < 7; i++ {
+			_ = mediator.SendOn(m, ctx, ping{})
+		}
+		failing = true
+		_ = mediator.SendOn(m, ctx, ping{})
+
+		// ASSERT
+		if wanted, got := 4, len(records()); wanted != got {
+			t.Errorf("wanted %d records (3 sampled and 1 error), got %d", wanted, got)
+		}
+	})
+
+	t.Run("custom redactor", func(t *testing.T) {
+		// ARRANGE
+		logger, records := capture()
+		m := mediator.New(mediator.WithBehaviors(Behavior(
+			WithLogger(logger),
+			WithRedactor(func(interface{}) slog.Value { return slog.StringValue("omitted") }),
+		)))
+		mediator.RegisterReceiverOn[login](m, mediator.ReceiverFunc[login](func(context.Context, login) error { return nil }))
+
+		// ACT
+		_ = mediator.SendOn(m, ctx, login{Password: "secret"})
+
+		// ASSERT
+		if got := records()[0]["request"]; got != "omitted" {
+			t.Errorf("wanted %q, got %v", "omitted", got)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package mediator
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Mediator maintains a registry of handlers, receivers, subscribers,
+// stream handlers, validators and pre- and post-processors.
+//
+// A Mediator is safe for concurrent use; handlers, receivers and
+// subscribers may be registered and removed (and the Mediator
+// configured) while requests are being performed, data sent or
+// notifications published.
+//
+// Each Mediator has its own registries, independent of any other
+// Mediator, so that (for example) tests running in parallel may each
+// use a separate Mediator without their registrations colliding.
+//
+// The package-level functions (RegisterHandler, RegisterReceiver,
+// Perform and Send etc) operate on a default Mediator.
+type Mediator struct {
+	mu             sync.Mutex
+	settings       atomic.Value // *config
+	requirements   []requirement
+	handlers       *registry
+	receivers      *registry
+	subscribers    *registry
+	streams        *registry
+	validators     *registry
+	preprocessors  *registry
+	postprocessors *registry
+	workers        *pool
+}
+
+// config holds the configuration of a Mediator.  A config is not
+// modified once stored by a Mediator; changes are applied to a copy
+// which then replaces the original.
+type config struct {
+	behaviors   []Behavior
+	publish     PublishStrategy
+	workers     int
+	queue       int
+	polymorphic bool
+	aggregate   bool
+	timeout     time.Duration
+	metrics     []Metrics
+	observers   []Observer
+	recovery    bool
+	report      func(context.Context, HandlerPanicError)
+}
+
+// Option is a function that configures a Mediator.
+type Option func(*config)
+
+// defaultMediator is the Mediator used by the package-level functions.
+var defaultMediator = New()
+
+// New returns a new Mediator, with empty registries, configured using
+// any options specified.
+func New(opts ...Option) *Mediator {
+	m := &Mediator{
+		handlers:       newRegistry(),
+		receivers:      newRegistry(),
+		subscribers:    newRegistry(),
+		streams:        newRegistry(),
+		validators:     newRegistry(),
+		preprocessors:  newRegistry(),
+		postprocessors: newRegistry(),
+		workers:        newPool(),
+	}
+	for _, r := range []*registry{m.handlers, m.receivers, m.subscribers, m.streams, m.validators, m.preprocessors, m.postprocessors} {
+		r.observe = m.observeRegistration
+	}
+	m.settings.Store(&config{
+		publish: SequentialStopOnFirstError,
+		queue:   -1,
+	})
+	m.Configure(opts...)
+	return m
+}
+
+// Default returns the Mediator used by the package-level functions.
+func Default() *Mediator {
+	return defaultMediator
+}
+
+// Configure applies the specified options to the default Mediator.
+func Configure(opts ...Option) {
+	defaultMediator.Configure(opts...)
+}
+
+// Configure applies the specified options to the Mediator.
+//
+// Requests, data or notifications already being dispatched when
+// the Mediator is configured are not affected by any changes.
+func (m *Mediator) Configure(opts ...Option) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cfg := *m.config()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	m.settings.Store(&cfg)
+}
+
+// config returns the current configuration of the Mediator.  The
+// returned config must not be modified.
+func (m *Mediator) config() *config {
+	return m.settings.Load().(*config)
+}
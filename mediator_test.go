@@ -0,0 +1,84 @@
+package mediator
+
+import (
+	"context"
+	"testing"
+)
+
+func TestThatNewReturnsAMediatorWithEmptyRegistries(t *testing.T) {
+	// ACT
+
+	m := New()
+
+	// ASSERT
+
+	if m.handlers.len() != 0 || m.receivers.len() != 0 {
+		t.Errorf("wanted 0 handlers and 0 receivers, got %d and %d", m.handlers.len(), m.receivers.len())
+	}
+}
+
+func TestThatNewAppliesOptions(t *testing.T) {
+	// ARRANGE
+
+	applied := false
+	opt := func(*config) { applied = true }
+
+	// ACT
+
+	New(opt)
+
+	// ASSERT
+
+	if !applied {
+		t.Error("option was not applied")
+	}
+}
+
+func TestThatMediatorRegistrationsAreIndependent(t *testing.T) {
+	// ARRANGE
+
+	m1 := New()
+	m2 := New()
+
+	RegisterHandlerOn[string, string](m1, &mockhandler[string, string]{execute: func(context.Context, string) (string, error) { return "one", nil }})
+	RegisterHandlerOn[string, string](m2, &mockhandler[string, string]{execute: func(context.Context, string) (string, error) { return "two", nil }})
+	RegisterReceiverOn[string](m1, &mockreceiver[string]{execute: func(context.Context, string) error { return nil }})
+
+	// ACT
+
+	r1, err1 := PerformOn[string, string](m1, context.Background(), "request")
+	r2, err2 := PerformOn[string, string](m2, context.Background(), "request")
+	errSend := SendOn(m2, context.Background(), "data")
+
+	// ASSERT
+
+	if err1 != nil || err2 != nil {
+		t.Fatalf("unexpected errors: %v, %v", err1, err2)
+	}
+	if r1 != "one" || r2 != "two" {
+		t.Errorf("wanted %q and %q, got %q and %q", "one", "two", r1, r2)
+	}
+	if _, ok := errSend.(NoReceiverError); !ok {
+		t.Errorf("wanted NoReceiverError, got %T", errSend)
+	}
+	if defaultMediator.handlers.len() != 0 || defaultMediator.receivers.len() != 0 {
+		t.Error("registrations leaked into the default mediator")
+	}
+}
+
+func TestThatDefaultReturnsTheDefaultMediator(t *testing.T) {
+	// ARRANGE
+
+	_, reg := MockHandlerReturningValues[string]("result", nil)
+	defer reg.Remove()
+
+	// ACT
+
+	result, err := PerformOn[string, string](Default(), context.Background(), "request")
+
+	// ASSERT
+
+	if err != nil || result != "result" {
+		t.Errorf("wanted %q, got %q (err: %v)", "result", result, err)
+	}
+}
@@ -0,0 +1,142 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Outcome classifies the result of performing a request or sending data.
+type Outcome int
+
+const (
+	// OutcomeSuccess indicates that no error was returned
+	OutcomeSuccess Outcome = iota + 1
+
+	// OutcomeValidationError indicates that a ValidationError was returned
+	OutcomeValidationError
+
+	// OutcomeExecutionError indicates that an error was returned by the
+	// handler or receiver (or a behavior), other than a ValidationError
+	OutcomeExecutionError
+
+	// OutcomeNoHandler indicates that there was no handler (or receiver)
+	// registered for the request (or data) type
+	OutcomeNoHandler
+
+	// OutcomeInvalidHandler indicates that the registered handler does
+	// not return the required result type, or that the handler could not
+	// be resolved unambiguously
+	OutcomeInvalidHandler
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeSuccess:
+		return "success"
+	case OutcomeValidationError:
+		return "validation_error"
+	case OutcomeExecutionError:
+		return "execution_error"
+	case OutcomeNoHandler:
+		return "no_handler"
+	case OutcomeInvalidHandler:
+		return "invalid_handler"
+	}
+	return fmt.Sprintf("Outcome(%d)", int(o))
+}
+
+// OutcomeOf returns the Outcome corresponding to an error returned by a
+// handler or receiver (or a behavior): OutcomeSuccess if the error is nil,
+// OutcomeValidationError if the error is a validation error (see
+// ErrValidation), or OutcomeExecutionError.
+//
+// OutcomeNoHandler and OutcomeInvalidHandler are determined by the
+// Mediator when resolving the handler (or receiver) and are never
+// returned by OutcomeOf; a NoHandlerError returned by a request performed
+// by a handler is an execution error of the request being performed by
+// that handler.
+func OutcomeOf(err error) Outcome {
+	switch {
+	case err == nil:
+		return OutcomeSuccess
+	case errors.Is(err, ErrValidation):
+		return OutcomeValidationError
+	}
+	return OutcomeExecutionError
+}
+
+// Measurement describes a request performed (or data sent) by a Mediator.
+type Measurement struct {
+	// Kind is HandlerKind for a request performed, or ReceiverKind for
+	// data sent
+	Kind Kind
+
+	// RequestType is the type of the request (or data); if the request
+	// is a nil interface, this is the interface type
+	RequestType reflect.Type
+
+	// Outcome classifies the result of the request
+	Outcome Outcome
+
+	// Duration is the time taken to perform the request
+	Duration time.Duration
+}
+
+// Metrics is the interface to be implemented to record a Measurement of
+// each request performed (or data sent) by a Mediator.  A Metrics must
+// be safe for concurrent use.
+type Metrics interface {
+	Record(context.Context, Measurement)
+}
+
+// WithMetrics configures a Mediator to record a Measurement of each
+// request performed or data sent (including requests performed by Ask,
+// PerformAsync or SendAsync) with each of the specified Metrics.
+func WithMetrics(metrics ...Metrics) Option {
+	return func(cfg *config) {
+		cfg.metrics = append(cfg.metrics[:len(cfg.metrics):len(cfg.metrics)], metrics...)
+	}
+}
+
+// measure returns a func to be deferred which records a Measurement of
+// a request of the specified type with any Metrics configured for the
+// Mediator.  The Outcome recorded is the outcome specified to the func,
+// if not zero, otherwise the OutcomeOf the error.
+//
+// If the request panics (i.e. the Mediator is not configured to recover
+// from panics), an OutcomeExecutionError is recorded and the panic is
+// then resumed.
+func (m *Mediator) measure(ctx context.Context, kind Kind, requesttype reflect.Type) func(*Outcome, *error) {
+	metrics := m.config().metrics
+	if len(metrics) == 0 {
+		return func(*Outcome, *error) {}
+	}
+
+	start := time.Now()
+	return func(outcome *Outcome, err *error) {
+		v := recover()
+		if v != nil {
+			*outcome = OutcomeExecutionError
+		}
+
+		ms := Measurement{
+			Kind:        kind,
+			RequestType: requesttype,
+			Outcome:     *outcome,
+			Duration:    time.Since(start),
+		}
+		if ms.Outcome == 0 {
+			ms.Outcome = OutcomeOf(*err)
+		}
+		for _, metric := range metrics {
+			metric.Record(ctx, ms)
+		}
+
+		if v != nil {
+			panic(v)
+		}
+	}
+}
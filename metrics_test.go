@@ -0,0 +1,199 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// recording is a Metrics that records the measurements made
+type recording struct {
+	mu           sync.Mutex
+	measurements []Measurement
+}
+
+func (r *recording) Record(_ context.Context, ms Measurement) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.measurements = append(r.measurements, ms)
+}
+
+func TestOutcomeOf(t *testing.T) {
+	testcases := []struct {
+		name   string
+		err    error
+		wanted Outcome
+	}{
+		{name: "nil", err: nil, wanted: OutcomeSuccess},
+		{name: "validation error", err: ValidationError{errors.New("invalid")}, wanted: OutcomeValidationError},
+		{name: "violations", err: Violations{{Path: "id"}}, wanted: OutcomeValidationError},
+		{name: "ErrValidation", err: ErrValidation, wanted: OutcomeValidationError},
+		{name: "execution error", err: errors.New("execution error"), wanted: OutcomeExecutionError},
+		{name: "wrapped validation error", err: fmt.Errorf("wrapped: %w", ValidationError{errors.New("invalid")}), wanted: OutcomeValidationError},
+		{name: "no handler error", err: NoHandlerError{}, wanted: OutcomeExecutionError},
+		{name: "invalid handler error", err: InvalidHandlerError{}, wanted: OutcomeExecutionError},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := OutcomeOf(tc.err); got != tc.wanted {
+				t.Errorf("wanted %v, got %v", tc.wanted, got)
+			}
+		})
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	// ARRANGE
+	ctx := context.Background()
+	metrics := &recording{}
+	m := New(WithMetrics(metrics))
+	RegisterHandlerOn[string, string](m, returning[string]("result"))
+	RegisterReceiverOn[int](m, &mockreceiver[int]{validate: func(context.Context, int) error { return errors.New("invalid") }})
+
+	// ACT
+	_, _ = PerformOn[string, string](m, ctx, "request")
+	_, _ = PerformOn[string, int](m, ctx, "request")
+	_, _ = PerformOn[bool, int](m, ctx, true)
+	_ = SendOn(m, ctx, 42)
+
+	// ASSERT
+	str, num, boolean := reflect.TypeOf(""), reflect.TypeOf(0), reflect.TypeOf(true)
+	wanted := []Measurement{
+		{Kind: HandlerKind, RequestType: str, Outcome: OutcomeSuccess},
+		{Kind: HandlerKind, RequestType: str, Outcome: OutcomeInvalidHandler},
+		{Kind: HandlerKind, RequestType: boolean, Outcome: OutcomeNoHandler},
+		{Kind: ReceiverKind, RequestType: num, Outcome: OutcomeValidationError},
+	}
+	got := metrics.measurements
+	for i := range got {
+		if got[i].Duration < 0 {
+			t.Errorf("measurement %d: wanted non-negative duration, got %v", i, got[i].Duration)
+		}
+		got[i].Duration = 0
+	}
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %v\ngot    %v", wanted, got)
+	}
+}
+
+func TestMetricsForNestedRequests(t *testing.T) {
+	// ARRANGE
+	ctx := context.Background()
+	metrics := &recording{}
+	m := New(WithMetrics(metrics))
+	HandleOn(m, func(ctx context.Context, rq string) (int, error) {
+		// performs a request for which there is no handler
+		return PerformOn[bool, int](m, ctx, true)
+	})
+	HandleOn(m, func(ctx context.Context, rq int) (int, error) {
+		// sends data which fails validation
+		if err := SendOn(m, ctx, "data"); err != nil {
+			return 0, fmt.Errorf("sending data: %w", err)
+		}
+		return rq, nil
+	})
+	RegisterReceiverOn[string](m, &mockreceiver[string]{validate: func(context.Context, string) error { return errors.New("invalid") }})
+
+	// ACT
+	_, _ = PerformOn[string, int](m, ctx, "request")
+	_, _ = PerformOn[int, int](m, ctx, 42)
+
+	// ASSERT
+	wanted := []Measurement{
+		{Kind: HandlerKind, RequestType: reflect.TypeOf(true), Outcome: OutcomeNoHandler},
+		{Kind: HandlerKind, RequestType: reflect.TypeOf(""), Outcome: OutcomeExecutionError},
+		{Kind: ReceiverKind, RequestType: reflect.TypeOf(""), Outcome: OutcomeValidationError},
+		{Kind: HandlerKind, RequestType: reflect.TypeOf(0), Outcome: OutcomeValidationError},
+	}
+	got := metrics.measurements
+	for i := range got {
+		got[i].Duration = 0
+	}
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %v\ngot    %v", wanted, got)
+	}
+}
+
+func TestMetricsForNilRequests(t *testing.T) {
+	// ARRANGE
+	ctx := context.Background()
+	metrics := &recording{}
+	m := New(WithMetrics(metrics))
+
+	// ACT
+	_, perr := PerformOn[any, int](m, ctx, nil)
+	serr := SendOn[any](m, ctx, nil)
+
+	// ASSERT
+	if !errors.Is(perr, ErrNoHandler) || !errors.Is(serr, ErrNoReceiver) {
+		t.Errorf("wanted no handler and no receiver errors, got %v and %v", perr, serr)
+	}
+	anytype := typeOf[any]()
+	wanted := []Measurement{
+		{Kind: HandlerKind, RequestType: anytype, Outcome: OutcomeNoHandler},
+		{Kind: ReceiverKind, RequestType: anytype, Outcome: OutcomeNoHandler},
+	}
+	got := metrics.measurements
+	for i := range got {
+		got[i].Duration = 0
+	}
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("\nwanted %v\ngot    %v", wanted, got)
+	}
+}
+
+func TestMetricsForPanickingRequests(t *testing.T) {
+	t.Run("panic is recorded and resumed", func(t *testing.T) {
+		// ARRANGE
+		metrics := &recording{}
+		m := New(WithMetrics(metrics))
+		RegisterHandlerOn[string, int](m, &mockhandler[string, int]{
+			execute: func(context.Context, string) (int, error) { panic("boom") },
+		})
+
+		// ACT
+		recovered := func() (v interface{}) {
+			defer func() { v = recover() }()
+			_, _ = PerformOn[string, int](m, context.Background(), "request")
+			return nil
+		}()
+
+		// ASSERT
+		if recovered != "boom" {
+			t.Errorf("wanted panic %q, got %v", "boom", recovered)
+		}
+		if len(metrics.measurements) != 1 || metrics.measurements[0].Outcome != OutcomeExecutionError {
+			t.Errorf("wanted 1 execution error measurement, got %v", metrics.measurements)
+		}
+	})
+
+	t.Run("async", func(t *testing.T) {
+		// ARRANGE
+		metrics := &recording{}
+		m := New(WithMetrics(metrics))
+		defer func() { _ = m.Shutdown(context.Background()) }()
+		RegisterReceiverOn[string](m, &mockreceiver[string]{
+			execute: func(context.Context, string) error { panic("boom") },
+		})
+
+		// ACT
+		future, err := SendAsyncOn(m, context.Background(), "data")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err = future.Await(context.Background())
+
+		// ASSERT
+		if !errors.Is(err, ErrHandlerPanic) {
+			t.Errorf("wanted HandlerPanicError, got %T (%[1]v)", err)
+		}
+		metrics.mu.Lock()
+		defer metrics.mu.Unlock()
+		if len(metrics.measurements) != 1 || metrics.measurements[0].Outcome != OutcomeExecutionError {
+			t.Errorf("wanted 1 execution error measurement, got %v", metrics.measurements)
+		}
+	})
+}
@@ -0,0 +1,211 @@
+package mediator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// PublishStrategy is a func that delivers a notification to subscribers,
+// returning any error(s) resulting from that delivery.
+//
+// A strategy is called with a func for each subscriber, in the order in
+// which subscribers were subscribed.  Each func delivers the notification
+// to a single subscriber, returning any error from that subscriber.
+type PublishStrategy func(ctx context.Context, deliveries []func(context.Context) error) error
+
+// SequentialStopOnFirstError is a PublishStrategy that delivers
+// a notification to each subscriber in turn, stopping at the first
+// subscriber that returns an error.  The error from that subscriber
+// is returned.
+//
+// This is the default PublishStrategy.
+func SequentialStopOnFirstError(ctx context.Context, deliveries []func(context.Context) error) error {
+	for _, deliver := range deliveries {
+		if err := deliver(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SequentialAggregateErrors is a PublishStrategy that delivers
+// a notification to each subscriber in turn, regardless of any errors.
+// If any subscribers return an error, a PublishError is returned
+// with the errors from all of those subscribers.
+func SequentialAggregateErrors(ctx context.Context, deliveries []func(context.Context) error) error {
+	errs := []error{}
+	for _, deliver := range deliveries {
+		if err := deliver(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return PublishError{errors: errs}
+	}
+	return nil
+}
+
+// ParallelStopOnFirstError is a PublishStrategy that delivers
+// a notification to all subscribers concurrently.  When a subscriber
+// returns an error, the context passed to all subscribers is cancelled
+// and the error from that subscriber is returned (once all subscribers
+// have returned).
+func ParallelStopOnFirstError(ctx context.Context, deliveries []func(context.Context) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var first error
+	once := sync.Once{}
+	parallel(ctx, deliveries, func(_ int, err error) {
+		once.Do(func() {
+			first = err
+			cancel()
+		})
+	})
+	return first
+}
+
+// ParallelAggregateErrors is a PublishStrategy that delivers
+// a notification to all subscribers concurrently.  If any subscribers
+// return an error, a PublishError is returned with the errors from all
+// of those subscribers (in the order in which the subscribers were
+// subscribed).
+func ParallelAggregateErrors(ctx context.Context, deliveries []func(context.Context) error) error {
+	results := make([]error, len(deliveries))
+	parallel(ctx, deliveries, func(i int, err error) {
+		results[i] = err
+	})
+
+	errs := []error{}
+	for _, err := range results {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return PublishError{errors: errs}
+	}
+	return nil
+}
+
+// parallel calls all of the specified deliveries concurrently, calling
+// the specified func with the index of any delivery returning an error,
+// together with that error, and returns when all deliveries have returned.
+func parallel(ctx context.Context, deliveries []func(context.Context) error, onError func(int, error)) {
+	wg := sync.WaitGroup{}
+	wg.Add(len(deliveries))
+	for i, deliver := range deliveries {
+		go func(i int, deliver func(context.Context) error) {
+			defer wg.Done()
+			if err := deliver(ctx); err != nil {
+				onError(i, err)
+			}
+		}(i, deliver)
+	}
+	wg.Wait()
+}
+
+// WithPublishStrategy is an Option that configures the strategy used
+// by a Mediator to deliver notifications to subscribers.
+func WithPublishStrategy(strategy PublishStrategy) Option {
+	return func(cfg *config) {
+		cfg.publish = strategy
+	}
+}
+
+// Subscribe subscribes the specified subscriber to notifications of a
+// particular type, published using the default Mediator.
+//
+// Any number of subscribers may be subscribed to the same notification
+// type.  Each subscription returns its own registration, which may be
+// removed without affecting any other subscribers.
+func Subscribe[TNotification any](subscriber Subscriber[TNotification], opts ...RegistrationOption) *Registration {
+	return SubscribeOn[TNotification](defaultMediator, subscriber, opts...)
+}
+
+// SubscribeOn subscribes the specified subscriber to notifications of a
+// particular type, published using the specified Mediator.
+func SubscribeOn[TNotification any](m *Mediator, subscriber Subscriber[TNotification], opts ...RegistrationOption) *Registration {
+	notificationtype := typeOf[TNotification]()
+
+	r := newReg(m.subscribers, SubscriberKind, notificationtype, subscriber, opts)
+	r.validate = validatorFor[TNotification](subscriber)
+	r.preprocess = preprocessorFor[TNotification](subscriber)
+	r.execute = func(ctx context.Context, notification interface{}) (interface{}, error) {
+		return nil, subscriber.Execute(ctx, notification.(TNotification))
+	}
+	m.subscribers.append(r)
+
+	return r
+}
+
+// Publish publishes the specified notification to all subscribers
+// subscribed to the notification type with the default Mediator.
+//
+// The notification is delivered to subscribers using the PublishStrategy
+// of the Mediator.  If there are no subscribers, Publish returns nil.
+//
+// If a subscriber implements Validator and the validator returns an
+// error, then that subscriber is not called and the error for that
+// subscriber will be a ValidationError, wrapping the error returned
+// by the validator.
+func Publish[TNotification any](ctx context.Context, notification TNotification) error {
+	return PublishOn(defaultMediator, ctx, notification)
+}
+
+// PublishOn publishes the specified notification to all subscribers
+// subscribed to the notification type with the specified Mediator.
+//
+// The notification is delivered to each subscriber in the same way as
+// for Publish.
+func PublishOn[TNotification any](m *Mediator, ctx context.Context, notification TNotification) error {
+	notificationtype := reflect.TypeOf(notification)
+
+	regs := m.subscribers.all(notificationtype)
+	if len(regs) == 0 {
+		return nil
+	}
+
+	d := Dispatch{Kind: SubscriberKind, Type: notificationtype, Request: notification}
+
+	deliveries := make([]func(context.Context) error, len(regs))
+	for i, reg := range regs {
+		reg := reg
+		deliveries[i] = func(ctx context.Context) error {
+			_, err := m.dispatch(ctx, d, reg, func(ctx context.Context) (interface{}, error) {
+				return m.call(ctx, d, reg, notification)
+			})
+			return err
+		}
+	}
+
+	return m.config().publish(ctx, deliveries)
+}
+
+// PublishError is returned by Publish when notifications are delivered
+// using a PublishStrategy that aggregates errors, if one or more
+// subscribers returned an error.
+type PublishError struct {
+	errors []error
+}
+
+func (e PublishError) Error() string {
+	msgs := make([]string, len(e.errors))
+	for i, err := range e.errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("publish error: %d subscriber(s) failed: %s", len(e.errors), strings.Join(msgs, "; "))
+}
+
+// Errors returns the errors returned by subscribers.
+func (e PublishError) Errors() []error {
+	return append([]error{}, e.errors...)
+}
+
+// Unwrap returns the errors returned by subscribers.
+func (e PublishError) Unwrap() []error {
+	return e.Errors()
+}
@@ -0,0 +1,252 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type notification struct {
+	id int
+}
+
+// subscriber returns a mock subscriber that records its name in the
+// specified slice of calls when executed, returning the specified error
+func subscriber(name string, calls *[]string, mu *sync.Mutex, err error) *mockreceiver[notification] {
+	return &mockreceiver[notification]{execute: func(context.Context, notification) error {
+		mu.Lock()
+		defer mu.Unlock()
+		*calls = append(*calls, name)
+		return err
+	}}
+}
+
+func TestThatPublishWithNoSubscribersReturnsNil(t *testing.T) {
+	// ACT
+
+	err := PublishOn(New(), context.Background(), notification{})
+
+	// ASSERT
+
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestThatPublishDeliversToAllSubscribers(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	s1 := &mockreceiver[notification]{execute: func(context.Context, notification) error { return nil }}
+	s2 := &mockreceiver[notification]{execute: func(context.Context, notification) error { return nil }}
+	SubscribeOn[notification](m, s1)
+	SubscribeOn[notification](m, s2)
+
+	// ACT
+
+	err := PublishOn(m, context.Background(), notification{id: 1})
+
+	// ASSERT
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !s1.Received(notification{id: 1}) || !s2.Received(notification{id: 1}) {
+		t.Error("notification was not delivered to all subscribers")
+	}
+}
+
+func TestThatRemovingASubscriptionDoesNotAffectOtherSubscribers(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	s1 := &mockreceiver[notification]{execute: func(context.Context, notification) error { return nil }}
+	s2 := &mockreceiver[notification]{execute: func(context.Context, notification) error { return nil }}
+	r1 := SubscribeOn[notification](m, s1)
+	SubscribeOn[notification](m, s2)
+
+	// ACT
+
+	r1.Remove()
+	err := PublishOn(m, context.Background(), notification{})
+
+	// ASSERT
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s1.WasCalled() || s2.WasNotCalled() {
+		t.Errorf("wanted removed subscriber not called and remaining subscriber called, got %v and %v", s1.WasCalled(), s2.WasCalled())
+	}
+}
+
+func TestThatPublishValidatesNotificationsForEachSubscriber(t *testing.T) {
+	// ARRANGE
+
+	m := New(WithPublishStrategy(SequentialAggregateErrors))
+	invalid := &mockreceiver[notification]{
+		execute:  func(context.Context, notification) error { return nil },
+		validate: func(context.Context, notification) error { return errors.New("invalid") },
+	}
+	valid := &mockreceiver[notification]{execute: func(context.Context, notification) error { return nil }}
+	SubscribeOn[notification](m, invalid)
+	SubscribeOn[notification](m, valid)
+
+	// ACT
+
+	err := PublishOn(m, context.Background(), notification{})
+
+	// ASSERT
+
+	if !errors.As(err, &ValidationError{}) {
+		t.Errorf("wanted ValidationError, got %T (%[1]v)", err)
+	}
+	if invalid.WasCalled() || valid.WasNotCalled() {
+		t.Errorf("wanted invalid subscriber not called and valid subscriber called, got %v and %v", invalid.WasCalled(), valid.WasCalled())
+	}
+}
+
+func TestPublishStrategies(t *testing.T) {
+	errFirst := errors.New("first")
+	errThird := errors.New("third")
+
+	testcases := []struct {
+		name     string
+		strategy PublishStrategy
+		calls    []string
+		assert   func(t *testing.T, err error)
+	}{
+		{name: "sequential, stop on first error", strategy: SequentialStopOnFirstError,
+			calls: []string{"first"},
+			assert: func(t *testing.T, err error) {
+				if err != errFirst {
+					t.Errorf("wanted %v, got %v", errFirst, err)
+				}
+			}},
+		{name: "sequential, aggregate errors", strategy: SequentialAggregateErrors,
+			calls: []string{"first", "second", "third"},
+			assert: func(t *testing.T, err error) {
+				pe := PublishError{}
+				if !errors.As(err, &pe) || !reflect.DeepEqual(pe.Errors(), []error{errFirst, errThird}) {
+					t.Errorf("wanted PublishError with %v, got %v", []error{errFirst, errThird}, err)
+				}
+			}},
+		{name: "parallel, stop on first error", strategy: ParallelStopOnFirstError,
+			calls: []string{"first", "second", "third"},
+			assert: func(t *testing.T, err error) {
+				if err != errFirst && err != errThird {
+					t.Errorf("wanted %v or %v, got %v", errFirst, errThird, err)
+				}
+			}},
+		{name: "parallel, aggregate errors", strategy: ParallelAggregateErrors,
+			calls: []string{"first", "second", "third"},
+			assert: func(t *testing.T, err error) {
+				pe := PublishError{}
+				if !errors.As(err, &pe) || !reflect.DeepEqual(pe.Errors(), []error{errFirst, errThird}) {
+					t.Errorf("wanted PublishError with %v, got %v", []error{errFirst, errThird}, err)
+				}
+				if !errors.Is(err, errFirst) || !errors.Is(err, errThird) {
+					t.Error("PublishError does not unwrap subscriber errors")
+				}
+			}},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			calls := []string{}
+			mu := &sync.Mutex{}
+			m := New(WithPublishStrategy(tc.strategy))
+			SubscribeOn[notification](m, subscriber("first", &calls, mu, errFirst))
+			SubscribeOn[notification](m, subscriber("second", &calls, mu, nil))
+			SubscribeOn[notification](m, subscriber("third", &calls, mu, errThird))
+
+			// ACT
+			err := PublishOn(m, context.Background(), notification{})
+
+			// ASSERT
+			tc.assert(t, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if len(calls) != len(tc.calls) {
+				t.Errorf("wanted calls %v, got %v", tc.calls, calls)
+			}
+		})
+	}
+}
+
+func TestThatParallelStopOnFirstErrorCancelsOtherSubscribers(t *testing.T) {
+	// ARRANGE
+
+	m := New(WithPublishStrategy(ParallelStopOnFirstError))
+	failed := errors.New("failed")
+	SubscribeOn[notification](m, &mockreceiver[notification]{execute: func(context.Context, notification) error {
+		return failed
+	}})
+	SubscribeOn[notification](m, &mockreceiver[notification]{execute: func(ctx context.Context, _ notification) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+			return errors.New("not cancelled")
+		}
+	}})
+
+	// ACT
+
+	err := PublishOn(m, context.Background(), notification{})
+
+	// ASSERT
+
+	if err != failed {
+		t.Errorf("wanted %v, got %v", failed, err)
+	}
+}
+
+func TestThatPublishDeliversThroughBehaviors(t *testing.T) {
+	// ARRANGE
+
+	dispatched := []Dispatch{}
+	mu := sync.Mutex{}
+	m := New(WithBehaviors(BehaviorFunc(func(ctx context.Context, d Dispatch, next Next) (interface{}, error) {
+		mu.Lock()
+		dispatched = append(dispatched, d)
+		mu.Unlock()
+		return next(ctx)
+	})))
+	SubscribeOn[notification](m, &mockreceiver[notification]{execute: func(context.Context, notification) error { return nil }})
+	SubscribeOn[notification](m, &mockreceiver[notification]{execute: func(context.Context, notification) error { return nil }})
+
+	// ACT
+
+	err := PublishOn(m, context.Background(), notification{})
+
+	// ASSERT
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dispatched) != 2 || dispatched[0].Kind != SubscriberKind {
+		t.Errorf("wanted 2 subscriber dispatches, got %v", dispatched)
+	}
+}
+
+func Test_PublishError(t *testing.T) {
+	// ARRANGE
+
+	err := PublishError{errors: []error{errors.New("first"), errors.New("second")}}
+
+	// ACT
+
+	got := err.Error()
+
+	// ASSERT
+
+	wanted := "publish error: 2 subscriber(s) failed: first; second"
+	if wanted != got {
+		t.Errorf("wanted %q, got %q", wanted, got)
+	}
+}
@@ -0,0 +1,93 @@
+package mediator
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// Observer is the interface to be implemented to observe the registrations
+// and dispatches of a Mediator, e.g. to build an audit trail or dashboard.
+//
+// Observers are notified synchronously and must be safe for concurrent
+// use.  NopObserver may be embedded in an implementation to provide
+// methods for events that are not of interest.
+//
+// Changes to the registrations of each kind (handlers, receivers etc)
+// are notified in the order in which they are made, so OnRegister for a
+// registration is always called before OnRemove for it.  When changes
+// are made concurrently (or by an observer) they may be notified on a
+// goroutine other than the one that made the change, after that change
+// has returned.
+type Observer interface {
+	// OnRegister is called when a handler, receiver, subscriber, stream
+	// handler, validator or processor is registered
+	OnRegister(*Registration)
+
+	// OnRemove is called when a registration is removed
+	OnRemove(*Registration)
+
+	// OnDispatchStart is called before a request (or data, or notification)
+	// is passed to the behaviors, validators and handler (or receiver etc)
+	OnDispatchStart(context.Context, Dispatch)
+
+	// OnValidationFailure is called when a request fails validation
+	OnValidationFailure(context.Context, Dispatch, error)
+
+	// OnDispatchEnd is called after a request has been dispatched, with the
+	// error (if any) and the time taken.  If the request panicked (and the
+	// Mediator is not configured to recover from panics) the error is a
+	// HandlerPanicError and the panic is resumed after the observers have
+	// been notified
+	OnDispatchEnd(context.Context, Dispatch, error, time.Duration)
+
+	// OnNoHandler is called when there is no handler (or receiver or stream
+	// handler) registered for a request
+	OnNoHandler(context.Context, Dispatch)
+}
+
+// NopObserver is an Observer that does nothing.  It may be embedded in
+// an Observer that observes only some events.
+type NopObserver struct{}
+
+func (NopObserver) OnRegister(*Registration)                                      {}
+func (NopObserver) OnRemove(*Registration)                                        {}
+func (NopObserver) OnDispatchStart(context.Context, Dispatch)                     {}
+func (NopObserver) OnValidationFailure(context.Context, Dispatch, error)          {}
+func (NopObserver) OnDispatchEnd(context.Context, Dispatch, error, time.Duration) {}
+func (NopObserver) OnNoHandler(context.Context, Dispatch)                         {}
+
+// WithObserver configures a Mediator to notify the specified observers of
+// registrations and dispatches.  Observers are notified only of events
+// occurring after they are configured.
+func WithObserver(observers ...Observer) Option {
+	return func(cfg *config) {
+		cfg.observers = append(cfg.observers[:len(cfg.observers):len(cfg.observers)], observers...)
+	}
+}
+
+// observeRegistration notifies observers of a registration being added
+// to, or removed from, a registry of the Mediator.
+func (m *Mediator) observeRegistration(r *Registration, added bool) {
+	for _, o := range m.config().observers {
+		if added {
+			o.OnRegister(r)
+		} else {
+			o.OnRemove(r)
+		}
+	}
+}
+
+// observeNoHandler notifies observers that there is no handler (or
+// receiver etc) for a request.
+func (m *Mediator) observeNoHandler(ctx context.Context, kind Kind, request interface{}) {
+	observers := m.config().observers
+	if len(observers) == 0 {
+		return
+	}
+
+	d := Dispatch{Kind: kind, Type: reflect.TypeOf(request), Request: request}
+	for _, o := range observers {
+		o.OnNoHandler(ctx, d)
+	}
+}
@@ -0,0 +1,213 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// eventlog is an Observer that records the events observed
+type eventlog struct {
+	NopObserver
+	mu     sync.Mutex
+	events []string
+}
+
+func (o *eventlog) record(format string, args ...interface{}) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, fmt.Sprintf(format, args...))
+}
+
+func (o *eventlog) OnRegister(r *Registration) { o.record("register %v %v", r.Kind(), r.Type()) }
+func (o *eventlog) OnRemove(r *Registration)   { o.record("remove %v %v", r.Kind(), r.Type()) }
+func (o *eventlog) OnDispatchStart(_ context.Context, d Dispatch) {
+	o.record("start %v %v", d.Kind, d.Type)
+}
+func (o *eventlog) OnValidationFailure(_ context.Context, d Dispatch, err error) {
+	o.record("invalid %v %v", d.Kind, d.Type)
+}
+func (o *eventlog) OnDispatchEnd(_ context.Context, d Dispatch, err error, _ time.Duration) {
+	o.record("end %v %v (%v)", d.Kind, d.Type, err != nil)
+}
+func (o *eventlog) OnNoHandler(_ context.Context, d Dispatch) {
+	o.record("no %v %v", d.Kind, d.Type)
+}
+
+func TestObserver(t *testing.T) {
+	// ARRANGE
+	ctx := context.Background()
+	observer := &eventlog{}
+	m := New(WithObserver(observer))
+
+	// ACT
+	reg := RegisterHandlerOn[string, string](m, returning[string]("result"))
+	_, _ = PerformOn[string, string](m, ctx, "request")
+	RegisterReceiverOn[int](m, &mockreceiver[int]{validate: func(context.Context, int) error { return errors.New("invalid") }})
+	_ = SendOn(m, ctx, 42)
+	_ = SendOn(m, ctx, true)
+	reg.Remove()
+	reg.Remove()
+
+	// ASSERT
+	wanted := []string{
+		"register handler string",
+		"start handler string",
+		"end handler string (false)",
+		"register receiver int",
+		"start receiver int",
+		"invalid receiver int",
+		"end receiver int (true)",
+		"no receiver bool",
+		"remove handler string",
+	}
+	if !reflect.DeepEqual(wanted, observer.events) {
+		t.Errorf("\nwanted %v\ngot    %v", wanted, observer.events)
+	}
+
+	t.Run("duplicate registration is not observed", func(t *testing.T) {
+		// ARRANGE
+		observer.events = nil
+
+		// ACT
+		_, err := TryRegisterReceiverOn[int](m, &mockreceiver[int]{})
+
+		// ASSERT
+		if err == nil || len(observer.events) > 0 {
+			t.Errorf("wanted error and no events, got %v (events %v)", err, observer.events)
+		}
+	})
+}
+
+// removing is an Observer that removes each registration when notified
+// of it, recording the order in which registrations are observed
+type removing struct {
+	eventlog
+}
+
+func (o *removing) OnRegister(r *Registration) {
+	r.Remove()
+	o.eventlog.OnRegister(r)
+}
+
+func TestObserverRegistrationOrder(t *testing.T) {
+	t.Run("changes made by an observer", func(t *testing.T) {
+		// ARRANGE
+		observer := &removing{}
+		m := New(WithObserver(observer))
+
+		// ACT
+		RegisterReceiverOn[int](m, &mockreceiver[int]{})
+
+		// ASSERT
+		wanted := []string{"register receiver int", "remove receiver int"}
+		if !reflect.DeepEqual(wanted, observer.events) {
+			t.Errorf("\nwanted %v\ngot    %v", wanted, observer.events)
+		}
+	})
+
+	t.Run("concurrent changes", func(t *testing.T) {
+		// ARRANGE
+		mu := sync.Mutex{}
+		registered := map[*Registration]bool{}
+		outoforder := 0
+		m := New(WithObserver(observerFuncs{
+			register: func(r *Registration) {
+				mu.Lock()
+				defer mu.Unlock()
+				registered[r] = true
+			},
+			remove: func(r *Registration) {
+				mu.Lock()
+				defer mu.Unlock()
+				if !registered[r] {
+					outoforder++
+				}
+			},
+		}))
+
+		// ACT
+		wg := sync.WaitGroup{}
+		for i := 0; i < 200; i++ {
+			reg := OverrideReceiverOn[int](m, &mockreceiver[int]{})
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				reg.Remove()
+			}()
+		}
+		wg.Wait()
+
+		// ASSERT
+		if outoforder > 0 {
+			t.Errorf("wanted OnRegister before OnRemove, got %d removals before registration", outoforder)
+		}
+	})
+}
+
+// observerFuncs is an Observer calling funcs when notified of
+// registrations being added and removed
+type observerFuncs struct {
+	NopObserver
+	register func(*Registration)
+	remove   func(*Registration)
+}
+
+func (o observerFuncs) OnRegister(r *Registration) { o.register(r) }
+func (o observerFuncs) OnRemove(r *Registration)   { o.remove(r) }
+
+func TestObserverValidationFailureDispatch(t *testing.T) {
+	// ARRANGE
+	ctx := context.Background()
+	observer := &eventlog{}
+	m := New(WithObserver(observer), WithPolymorphicDispatch())
+	RegisterReceiverOn[int](m, &mockreceiver[int]{validate: func(context.Context, int) error { return errors.New("invalid") }})
+
+	// ACT
+	n := 42
+	_ = SendOn(m, ctx, &n)
+
+	// ASSERT
+	wanted := []string{
+		"register receiver int",
+		"start receiver *int",
+		"invalid receiver *int",
+		"end receiver *int (true)",
+	}
+	if !reflect.DeepEqual(wanted, observer.events) {
+		t.Errorf("\nwanted %v\ngot    %v", wanted, observer.events)
+	}
+}
+
+func TestObserverPanickingDispatch(t *testing.T) {
+	// ARRANGE
+	observer := &eventlog{}
+	m := New(WithObserver(observer))
+	RegisterHandlerOn[string, int](m, &mockhandler[string, int]{
+		execute: func(context.Context, string) (int, error) { panic("boom") },
+	})
+
+	// ACT
+	recovered := func() (v interface{}) {
+		defer func() { v = recover() }()
+		_, _ = PerformOn[string, int](m, context.Background(), "request")
+		return nil
+	}()
+
+	// ASSERT
+	if recovered != "boom" {
+		t.Errorf("wanted panic %q, got %v", "boom", recovered)
+	}
+	wanted := []string{
+		"register handler string",
+		"start handler string",
+		"end handler string (true)",
+	}
+	if !reflect.DeepEqual(wanted, observer.events) {
+		t.Errorf("\nwanted %v\ngot    %v", wanted, observer.events)
+	}
+}
@@ -0,0 +1,107 @@
+package mediator
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOverrideHandler(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	RegisterHandlerOn[string, string](m, returning[string]("production"))
+
+	perform := func() string {
+		result, err := PerformOn[string, string](m, context.Background(), "request")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result
+	}
+
+	// ACT
+
+	override := OverrideHandlerOn[string, string](m, returning[string]("override"))
+
+	// ASSERT
+
+	if got := perform(); got != "override" {
+		t.Errorf("wanted %q, got %q", "override", got)
+	}
+
+	t.Run("overrides may be overridden", func(t *testing.T) {
+		nested := OverrideHandlerOn[string, string](m, returning[string]("nested"))
+		if got := perform(); got != "nested" {
+			t.Errorf("wanted %q, got %q", "nested", got)
+		}
+
+		nested.Remove()
+		if got := perform(); got != "override" {
+			t.Errorf("wanted %q, got %q", "override", got)
+		}
+	})
+
+	t.Run("removing the override restores the overridden handler", func(t *testing.T) {
+		override.Remove()
+		if got := perform(); got != "production" {
+			t.Errorf("wanted %q, got %q", "production", got)
+		}
+	})
+
+	t.Run("registering a handler for an overridden type panics", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("did not panic")
+			}
+		}()
+		OverrideHandlerOn[string, string](m, returning[string]("override"))
+		RegisterHandlerOn[string, string](m, returning[string]("other"))
+	})
+}
+
+func TestOverrideHandlerWithNoExistingHandler(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+
+	// ACT
+
+	reg := OverrideHandlerOn[string, string](m, returning[string]("override"))
+
+	// ASSERT
+
+	result, err := PerformOn[string, string](m, context.Background(), "request")
+	if err != nil || result != "override" {
+		t.Errorf("wanted %q, got %q (err: %v)", "override", result, err)
+	}
+
+	reg.Remove()
+	if m.handlers.len() != 0 {
+		t.Error("override was not removed")
+	}
+}
+
+func TestOverrideReceiver(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	production := &mockreceiver[string]{execute: func(context.Context, string) error { return nil }}
+	override := &mockreceiver[string]{execute: func(context.Context, string) error { return nil }}
+	RegisterReceiverOn[string](m, production)
+
+	// ACT
+
+	reg := OverrideReceiverOn[string](m, override)
+	_ = SendOn(m, context.Background(), "overridden")
+	reg.Remove()
+	_ = SendOn(m, context.Background(), "restored")
+
+	// ASSERT
+
+	if !override.Received("overridden") || override.Received("restored") {
+		t.Errorf("wanted override to receive only %q, got %v", "overridden", override.DataReceived())
+	}
+	if !production.Received("restored") || production.Received("overridden") {
+		t.Errorf("wanted production to receive only %q, got %v", "restored", production.DataReceived())
+	}
+}
@@ -0,0 +1,143 @@
+package mediator
+
+import (
+	"context"
+)
+
+// PreProcessor[TRequest] is an optional interface that may be implemented
+// by handlers, receivers and subscribers (or added to a Mediator using
+// AddPreProcessor) to process a request (or data) after it has been
+// validated and before it is executed.
+//
+// If a pre-processor returns an error, the request is not executed and
+// the error is returned.
+type PreProcessor[TRequest any] interface {
+	PreProcess(context.Context, TRequest) error
+}
+
+// PostProcessor[TRequest, TResult] is an optional interface that may be
+// implemented by handlers (or added to a Mediator using AddPostProcessor)
+// to observe the result and error returned by a handler for a request.
+type PostProcessor[TRequest any, TResult any] interface {
+	PostProcess(context.Context, TRequest, TResult, error)
+}
+
+// PreProcessorFunc is a func that implements PreProcessor.
+type PreProcessorFunc[TRequest any] func(context.Context, TRequest) error
+
+// PreProcess calls the func.
+func (fn PreProcessorFunc[TRequest]) PreProcess(ctx context.Context, request TRequest) error {
+	return fn(ctx, request)
+}
+
+// PostProcessorFunc is a func that implements PostProcessor.
+type PostProcessorFunc[TRequest any, TResult any] func(context.Context, TRequest, TResult, error)
+
+// PostProcess calls the func.
+func (fn PostProcessorFunc[TRequest, TResult]) PostProcess(ctx context.Context, request TRequest, result TResult, err error) {
+	fn(ctx, request, result, err)
+}
+
+// AddPreProcessor adds a pre-processor for the specified request (or
+// data) type to the default Mediator.
+//
+// Any number of pre-processors may be added for a type.  Pre-processors
+// are called in the order in which they were added, before any
+// pre-processor implemented by the handler (or receiver or subscriber)
+// itself.
+//
+// The returned Registration may be used to remove the pre-processor.
+func AddPreProcessor[TRequest any](processor PreProcessor[TRequest]) *Registration {
+	return AddPreProcessorOn[TRequest](defaultMediator, processor)
+}
+
+// AddPreProcessorOn adds a pre-processor for the specified request (or
+// data) type to the specified Mediator, in the same way as AddPreProcessor.
+func AddPreProcessorOn[TRequest any](m *Mediator, processor PreProcessor[TRequest]) *Registration {
+	r := newReg(m.preprocessors, PreProcessorKind, typeOf[TRequest](), processor, nil)
+	r.preprocess = preprocessorFor[TRequest](processor)
+	m.preprocessors.append(r)
+
+	return r
+}
+
+// AddPostProcessor adds a post-processor for the specified request and
+// result type to the default Mediator.
+//
+// Any number of post-processors may be added for a type.  Post-processors
+// are called after any post-processor implemented by the handler itself,
+// in the order in which they were added.  A post-processor is called only
+// for a handler returning the result type of the post-processor.
+//
+// The returned Registration may be used to remove the post-processor.
+func AddPostProcessor[TRequest any, TResult any](processor PostProcessor[TRequest, TResult]) *Registration {
+	return AddPostProcessorOn[TRequest, TResult](defaultMediator, processor)
+}
+
+// AddPostProcessorOn adds a post-processor for the specified request and
+// result type to the specified Mediator, in the same way as
+// AddPostProcessor.
+func AddPostProcessorOn[TRequest any, TResult any](m *Mediator, processor PostProcessor[TRequest, TResult]) *Registration {
+	r := newReg(m.postprocessors, PostProcessorKind, typeOf[TRequest](), processor, nil)
+	r.resulttype = typeOf[TResult]()
+	r.postprocess = postprocessorFor[TRequest, TResult](processor)
+	m.postprocessors.append(r)
+
+	return r
+}
+
+// preprocess calls the pre-processors for a registration, stopping at
+// the first that returns an error.
+func (m *Mediator) preprocess(ctx context.Context, r *Registration, request interface{}) error {
+	for _, p := range m.preprocessors.all(r.registeredtype) {
+		if err := p.preprocess(ctx, request); err != nil {
+			return err
+		}
+	}
+	if r.preprocess != nil {
+		return r.preprocess(ctx, request)
+	}
+	return nil
+}
+
+// postprocess calls the post-processors for a registration of a handler.
+func (m *Mediator) postprocess(ctx context.Context, r *Registration, request interface{}, result interface{}, err error) {
+	if r.kind != HandlerKind {
+		return
+	}
+	if r.postprocess != nil {
+		r.postprocess(ctx, request, result, err)
+	}
+	for _, p := range m.postprocessors.all(r.registeredtype) {
+		if p.resulttype == r.resulttype {
+			p.postprocess(ctx, request, result, err)
+		}
+	}
+}
+
+// preprocessorFor returns a func that pre-processes a request using the
+// specified implementation, if it implements PreProcessor[TRequest],
+// otherwise nil.
+func preprocessorFor[TRequest any](impl interface{}) func(context.Context, interface{}) error {
+	p, ok := impl.(PreProcessor[TRequest])
+	if !ok {
+		return nil
+	}
+	return func(ctx context.Context, request interface{}) error {
+		return p.PreProcess(ctx, request.(TRequest))
+	}
+}
+
+// postprocessorFor returns a func that post-processes a request and result
+// using the specified implementation, if it implements
+// PostProcessor[TRequest, TResult], otherwise nil.
+func postprocessorFor[TRequest any, TResult any](impl interface{}) func(context.Context, interface{}, interface{}, error) {
+	p, ok := impl.(PostProcessor[TRequest, TResult])
+	if !ok {
+		return nil
+	}
+	return func(ctx context.Context, request interface{}, result interface{}, err error) {
+		res, _ := result.(TResult)
+		p.PostProcess(ctx, request.(TRequest), res, err)
+	}
+}
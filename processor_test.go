@@ -0,0 +1,124 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// processinghandler is a handler that implements PreProcessor and
+// PostProcessor, recording calls in the calls slice
+type processinghandler struct {
+	calls *[]string
+	err   error
+}
+
+func (h processinghandler) Execute(_ context.Context, rq string) (int, error) {
+	*h.calls = append(*h.calls, "execute")
+	return len(rq), h.err
+}
+
+func (h processinghandler) PreProcess(context.Context, string) error {
+	*h.calls = append(*h.calls, "handler pre-process")
+	return nil
+}
+
+func (h processinghandler) PostProcess(_ context.Context, _ string, result int, err error) {
+	*h.calls = append(*h.calls, "handler post-process")
+}
+
+func TestProcessors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("are called around the handler", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		calls := []string{}
+		var observed int
+		var observedErr error
+		RegisterHandlerOn[string, int](m, processinghandler{calls: &calls, err: errors.New("handler error")})
+		AddPreProcessorOn[string](m, PreProcessorFunc[string](func(context.Context, string) error {
+			calls = append(calls, "pre-process")
+			return nil
+		}))
+		AddPostProcessorOn[string, int](m, PostProcessorFunc[string, int](func(_ context.Context, _ string, result int, err error) {
+			calls = append(calls, "post-process")
+			observed, observedErr = result, err
+		}))
+		AddPostProcessorOn[string, bool](m, PostProcessorFunc[string, bool](func(context.Context, string, bool, error) {
+			calls = append(calls, "post-process (wrong result type)")
+		}))
+
+		// ACT
+		_, err := PerformOn[string, int](m, ctx, "request")
+
+		// ASSERT
+		if err == nil {
+			t.Error("wanted error, got nil")
+		}
+		wanted := []string{"pre-process", "handler pre-process", "execute", "handler post-process", "post-process"}
+		if !reflect.DeepEqual(wanted, calls) {
+			t.Errorf("\nwanted %v\ngot    %v", wanted, calls)
+		}
+		if observed != 7 || observedErr != err {
+			t.Errorf("wanted post-processor to observe 7 and %v, got %d and %v", err, observed, observedErr)
+		}
+	})
+
+	t.Run("pre-processor returning an error", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		perr := errors.New("pre-processor error")
+		receiver := accepting[string]()
+		RegisterReceiverOn[string](m, receiver)
+		AddPreProcessorOn[string](m, PreProcessorFunc[string](func(context.Context, string) error { return perr }))
+
+		// ACT
+		err := SendOn(m, ctx, "data")
+
+		// ASSERT
+		if err != perr {
+			t.Errorf("wanted %v, got %v", perr, err)
+		}
+		if receiver.WasCalled() {
+			t.Error("receiver was called")
+		}
+	})
+
+	t.Run("are not called if validation fails", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		calls := []string{}
+		RegisterHandlerOn[string, int](m, processinghandler{calls: &calls})
+		AddValidatorOn[string](m, ValidatorFunc[string](func(context.Context, string) error { return errors.New("invalid") }))
+
+		// ACT
+		_, err := PerformOn[string, int](m, ctx, "request")
+
+		// ASSERT
+		if !errors.Is(err, ErrValidation) || len(calls) > 0 {
+			t.Errorf("wanted ValidationError and no calls, got %v (calls %v)", err, calls)
+		}
+	})
+
+	t.Run("removing a processor", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		calls := 0
+		RegisterReceiverOn[string](m, accepting[string]())
+		reg := AddPreProcessorOn[string](m, PreProcessorFunc[string](func(context.Context, string) error {
+			calls++
+			return nil
+		}))
+
+		// ACT
+		reg.Remove()
+		_ = SendOn(m, ctx, "data")
+
+		// ASSERT
+		if calls > 0 {
+			t.Error("removed pre-processor was called")
+		}
+	})
+}
@@ -0,0 +1,125 @@
+// Package prommetrics provides a mediator.Metrics that records the count,
+// latency and outcomes of requests using Prometheus collectors.
+package prommetrics
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/blugnu/go-mediator"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Labels of the metrics collected.
+const (
+	KindLabel        = "kind"
+	RequestTypeLabel = "request_type"
+	OutcomeLabel     = "outcome"
+)
+
+// Option configures the Metrics.
+type Option func(*config)
+
+type config struct {
+	namespace string
+	subsystem string
+	buckets   []float64
+}
+
+// WithNamespace specifies the namespace of the metrics (default:
+// "mediator").
+func WithNamespace(namespace string) Option {
+	return func(cfg *config) {
+		cfg.namespace = namespace
+	}
+}
+
+// WithSubsystem specifies the subsystem of the metrics (default: none).
+func WithSubsystem(subsystem string) Option {
+	return func(cfg *config) {
+		cfg.subsystem = subsystem
+	}
+}
+
+// WithBuckets specifies the buckets (in seconds) of the latency histogram
+// (default: prometheus.DefBuckets).
+func WithBuckets(buckets ...float64) Option {
+	return func(cfg *config) {
+		cfg.buckets = buckets
+	}
+}
+
+// Metrics is a mediator.Metrics and a prometheus.Collector, collecting:
+//
+//	mediator_requests_total{kind, request_type, outcome}
+//	mediator_request_duration_seconds{kind, request_type, outcome}
+//
+// The Metrics must be registered with a prometheus.Registerer to be
+// exposed:
+//
+//	metrics := prommetrics.New()
+//	prometheus.MustRegister(metrics)
+//	mediator.Configure(mediator.WithMetrics(metrics))
+type Metrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// New returns a new Metrics, configured using any options specified.
+func New(opts ...Option) *Metrics {
+	cfg := &config{
+		namespace: "mediator",
+		buckets:   prometheus.DefBuckets,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	labels := []string{KindLabel, RequestTypeLabel, OutcomeLabel}
+	return &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Subsystem: cfg.subsystem,
+			Name:      "requests_total",
+			Help:      "The number of requests performed (or data sent) by kind, request type and outcome.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.namespace,
+			Subsystem: cfg.subsystem,
+			Name:      "request_duration_seconds",
+			Help:      "The time taken to perform requests (or send data) by kind, request type and outcome.",
+			Buckets:   cfg.buckets,
+		}, labels),
+	}
+}
+
+// Record records a measurement.
+func (m *Metrics) Record(_ context.Context, ms mediator.Measurement) {
+	labels := prometheus.Labels{
+		KindLabel:        ms.Kind.String(),
+		RequestTypeLabel: typeName(ms.RequestType),
+		OutcomeLabel:     ms.Outcome.String(),
+	}
+	m.requests.With(labels).Inc()
+	m.duration.With(labels).Observe(ms.Duration.Seconds())
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.duration.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.duration.Collect(ch)
+}
+
+// typeName returns the name of the specified type, or "<nil>" if nil
+func typeName(t reflect.Type) string {
+	if t == nil {
+		return "<nil>"
+	}
+	return t.String()
+}
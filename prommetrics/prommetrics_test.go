@@ -0,0 +1,75 @@
+package prommetrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/blugnu/go-mediator"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	// ARRANGE
+	metrics := New(WithBuckets(1))
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(metrics)
+
+	ctx := context.Background()
+	m := mediator.New(mediator.WithMetrics(metrics))
+	mediator.HandleOn(m, func(_ context.Context, fail bool) (int, error) {
+		if fail {
+			return 0, errors.New("failed")
+		}
+		return 1, nil
+	})
+
+	// ACT
+	_, _ = mediator.PerformOn[bool, int](m, ctx, false)
+	_, _ = mediator.PerformOn[bool, int](m, ctx, false)
+	_, _ = mediator.PerformOn[bool, int](m, ctx, true)
+	_ = mediator.SendOn(m, ctx, "data")
+
+	// ASSERT
+	wanted := `
+# HELP mediator_requests_total The number of requests performed (or data sent) by kind, request type and outcome.
+# TYPE mediator_requests_total counter
+mediator_requests_total{kind="handler",outcome="execution_error",request_type="bool"} 1
+mediator_requests_total{kind="handler",outcome="success",request_type="bool"} 2
+mediator_requests_total{kind="receiver",outcome="no_handler",request_type="string"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(wanted), "mediator_requests_total"); err != nil {
+		t.Error(err)
+	}
+
+	if wanted, got := 3, testutil.CollectAndCount(metrics.duration); wanted != got {
+		t.Errorf("wanted %d histograms, got %d", wanted, got)
+	}
+}
+
+func TestMetricsWithNilRequests(t *testing.T) {
+	// ARRANGE
+	metrics := New()
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(metrics)
+
+	ctx := context.Background()
+	m := mediator.New(mediator.WithMetrics(metrics))
+
+	// ACT
+	_, _ = mediator.PerformOn[any, int](m, ctx, nil)
+	metrics.Record(ctx, mediator.Measurement{Kind: mediator.ReceiverKind, Outcome: mediator.OutcomeNoHandler})
+
+	// ASSERT
+	wanted := `
+# HELP mediator_requests_total The number of requests performed (or data sent) by kind, request type and outcome.
+# TYPE mediator_requests_total counter
+mediator_requests_total{kind="handler",outcome="no_handler",request_type="interface {}"} 1
+mediator_requests_total{kind="receiver",outcome="no_handler",request_type="<nil>"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(wanted), "mediator_requests_total"); err != nil {
+		t.Error(err)
+	}
+}
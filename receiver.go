@@ -2,56 +2,142 @@ package mediator
 
 import (
 	"context"
-	"fmt"
 	"reflect"
 )
 
-// RegisterReceiver registers the specified handler for a particular request type.
+// RegisterReceiver registers the specified handler for a particular request type
+// with the default Mediator.
 //
-// If a handler is already registered for that type the function will panic, otherwise
-// the handler is registered.
-func RegisterReceiver[TData any](handler Receiver[TData]) *reg {
-	var data TData
-	datatype := reflect.TypeOf(data)
+// The data type may be an interface, in which case the receiver may be used
+// for data of any type implementing that interface when using polymorphic
+// dispatch (see WithPolymorphicDispatch).
+//
+// If a handler is already registered for that type the function will panic with a
+// DuplicateRegistrationError, otherwise the handler is registered.
+func RegisterReceiver[TData any](handler Receiver[TData], opts ...RegistrationOption) *Registration {
+	return RegisterReceiverOn[TData](defaultMediator, handler, opts...)
+}
 
-	_, exists := receivers[datatype]
-	if exists {
-		panic(fmt.Sprintf("receiver already registered for %T", data))
+// RegisterReceiverOn registers the specified handler for a particular request type
+// with the specified Mediator.
+//
+// If a handler is already registered with the Mediator for that type the function
+// will panic with a DuplicateRegistrationError, otherwise the handler is registered.
+func RegisterReceiverOn[TData any](m *Mediator, handler Receiver[TData], opts ...RegistrationOption) *Registration {
+	r, err := TryRegisterReceiverOn[TData](m, handler, opts...)
+	if err != nil {
+		panic(err)
 	}
 
-	receivers[datatype] = handler
+	return r
+}
+
+// TryRegisterReceiver registers a receiver for the specified data type
+// with the default Mediator.
+//
+// If a receiver is already registered for the data type, the receiver
+// is not registered and a DuplicateRegistrationError is returned.
+func TryRegisterReceiver[TData any](receiver Receiver[TData], opts ...RegistrationOption) (*Registration, error) {
+	return TryRegisterReceiverOn[TData](defaultMediator, receiver, opts...)
+}
+
+// TryRegisterReceiverOn registers a receiver for the specified data type
+// with the specified Mediator.
+//
+// If a receiver is already registered with the Mediator for the data
+// type, the receiver is not registered and a DuplicateRegistrationError
+// is returned.
+func TryRegisterReceiverOn[TData any](m *Mediator, receiver Receiver[TData], opts ...RegistrationOption) (*Registration, error) {
+	r := receiverReg(m, receiver, opts)
+	if err := register(r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// OverrideReceiver registers a receiver for the specified data type with
+// the default Mediator, overriding any receiver already registered for
+// the data type.
+//
+// The overridden receiver is not removed; when the registration of the
+// overriding receiver is removed, the overridden receiver is restored.
+// Overrides may themselves be overridden.
+//
+// If no receiver is registered for the data type, the receiver is
+// registered as if by RegisterReceiver.
+func OverrideReceiver[TData any](receiver Receiver[TData], opts ...RegistrationOption) *Registration {
+	return OverrideReceiverOn[TData](defaultMediator, receiver, opts...)
+}
+
+// OverrideReceiverOn registers a receiver for the specified data type with
+// the specified Mediator, overriding any receiver already registered for
+// the data type.
+//
+// The overridden receiver is restored when the registration of the
+// overriding receiver is removed, as for OverrideReceiver.
+func OverrideReceiverOn[TData any](m *Mediator, receiver Receiver[TData], opts ...RegistrationOption) *Registration {
+	r := receiverReg(m, receiver, opts)
+	m.receivers.append(r)
+
+	return r
+}
 
-	return &reg{
-		registry:       receivers,
-		registeredtype: datatype,
+// receiverReg returns a registration of a receiver with a Mediator
+func receiverReg[TData any](m *Mediator, receiver Receiver[TData], opts []RegistrationOption) *Registration {
+	r := newReg(m.receivers, ReceiverKind, typeOf[TData](), receiver, opts)
+	r.validate = validatorFor[TData](receiver)
+	r.preprocess = preprocessorFor[TData](receiver)
+	r.execute = func(ctx context.Context, data interface{}) (interface{}, error) {
+		return nil, receiver.Execute(ctx, data.(TData))
 	}
+	return r
 }
 
-// Send sends the specified data and context to the registered receiver
-// for the data type and returns any error returned by the recevier.
+// Send sends the specified data and context to the receiver registered
+// with the default Mediator for the data type and returns any error
+// returned by the recevier.
 //
 // If the receiver implements Validator and the validator returns an error,
 // then receiver is not called and the error returned by Send will be a
 // ValidationError, wrapping the error returned by the validator.
+//
+// The data is passed through any behaviors added to the Mediator or
+// to the receiver registration before being validated and executed.
 func Send[TData any](ctx context.Context, data TData) error {
-	datatype := reflect.TypeOf(data)
+	return SendOn(defaultMediator, ctx, data)
+}
+
+// SendOn sends the specified data and context to the receiver registered
+// with the specified Mediator for the data type and returns any error
+// returned by the receiver.
+//
+// The data is validated (if the receiver implements Validator) in the
+// same way as for Send.
+func SendOn[TData any](m *Mediator, ctx context.Context, data TData) (err error) {
+	var outcome Outcome
+	defer m.measure(ctx, ReceiverKind, requestType(data))(&outcome, &err)
 
-	receiver, ok := receivers[datatype].(Receiver[TData])
-	if !ok {
-		return NoReceiverError{data: data}
+	reg, rd, err := m.resolve(m.receivers, data)
+	if err != nil {
+		outcome = OutcomeInvalidHandler
+		return err
+	}
+	if reg == nil {
+		outcome = OutcomeNoHandler
+		m.observeNoHandler(ctx, ReceiverKind, data)
+		return NoReceiverError{datatype: reflect.TypeOf(data)}
 	}
 
-	// You may be thinking that we should test that the handler we found is
-	// of the correct type, but the magic of generics and the strict type
-	// system takes care of that for us, so there's no need.  \o/
+	// You may be thinking that we should test that the receiver we found
+	// accepts the data, but the receiver was registered for the type of
+	// the data (or, with polymorphic dispatch, a type to which the data
+	// was resolved) so there's no need.  \o/
 
-	// If the handler also provides a request validator call that first
-	if validator, ok := receiver.(Validator[TData]); ok {
-		err := validate(validator, ctx, data)
-		if err != nil {
-			return err
-		}
-	}
+	d := Dispatch{Kind: ReceiverKind, Type: reflect.TypeOf(data), Request: data}
+	_, err = m.dispatch(ctx, d, reg, func(ctx context.Context) (interface{}, error) {
+		return m.call(ctx, d, reg, rd)
+	})
 
-	return receiver.Execute(ctx, data)
+	return err
 }
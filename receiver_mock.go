@@ -3,25 +3,27 @@ package mediator
 import (
 	"context"
 	"reflect"
+	"sync"
 )
 
 type mockreceiver[TData any] struct {
+	mu       sync.Mutex
 	received []TData
 	validate func(context.Context, TData) error
 	execute  func(context.Context, TData) error
 }
 
-func MockReceiver[TData any]() (*mockreceiver[TData], *reg) {
+func MockReceiver[TData any]() (*mockreceiver[TData], *Registration) {
 	return MockReceiverReturningError[TData](nil)
 }
 
-func MockReceiverWithFunc[TData any](executor ReceiverFunc[TData]) (*mockreceiver[TData], *reg) {
+func MockReceiverWithFunc[TData any](executor ReceiverFunc[TData]) (*mockreceiver[TData], *Registration) {
 	h := &mockreceiver[TData]{execute: executor}
 	r := RegisterReceiver[TData](h)
 	return h, r
 }
 
-func MockReceiverWithValidator[TData any](executor ReceiverFunc[TData], validator ValidatorFunc[TData]) (*mockreceiver[TData], *reg) {
+func MockReceiverWithValidator[TData any](executor ReceiverFunc[TData], validator ValidatorFunc[TData]) (*mockreceiver[TData], *Registration) {
 	h := &mockreceiver[TData]{
 		execute:  executor,
 		validate: validator,
@@ -30,11 +32,11 @@ func MockReceiverWithValidator[TData any](executor ReceiverFunc[TData], validato
 	return h, r
 }
 
-func MockReceiverReturningError[TData any](err error) (*mockreceiver[TData], *reg) {
+func MockReceiverReturningError[TData any](err error) (*mockreceiver[TData], *Registration) {
 	return MockReceiverWithFunc(func(context.Context, TData) error { return err })
 }
 
-func MockReceiverWithValidatorError[TData any](err error) (*mockreceiver[TData], *reg) {
+func MockReceiverWithValidatorError[TData any](err error) (*mockreceiver[TData], *Registration) {
 	return MockReceiverWithValidator(
 		func(context.Context, TData) error { return nil },
 		func(context.Context, TData) error { return err },
@@ -42,7 +44,10 @@ func MockReceiverWithValidatorError[TData any](err error) (*mockreceiver[TData],
 }
 
 func (mock *mockreceiver[TData]) Execute(ctx context.Context, request TData) error {
+	mock.mu.Lock()
 	mock.received = append(mock.received, request)
+	mock.mu.Unlock()
+
 	return mock.execute(ctx, request)
 }
 
@@ -54,6 +59,8 @@ func (mock *mockreceiver[TData]) Validate(ctx context.Context, request TData) er
 }
 
 func (mock *mockreceiver[TData]) Received(data TData) bool {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
 	for _, received := range mock.received {
 		if reflect.DeepEqual(received, data) {
 			return true
@@ -63,13 +70,19 @@ func (mock *mockreceiver[TData]) Received(data TData) bool {
 }
 
 func (mock *mockreceiver[TData]) DataReceived() []TData {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
 	return append([]TData{}, mock.received...)
 }
 
 func (mock *mockreceiver[TData]) WasCalled() bool {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
 	return len(mock.received) > 0
 }
 
 func (mock *mockreceiver[TData]) WasNotCalled() bool {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
 	return len(mock.received) == 0
 }
@@ -9,7 +9,7 @@ import (
 
 func TestMockReceiver(t *testing.T) {
 
-	if len(receivers) > 0 {
+	if defaultMediator.receivers.len() > 0 {
 		t.Fatal("invalid test: one or more receivers are already registered")
 	}
 
@@ -21,7 +21,7 @@ func TestMockReceiver(t *testing.T) {
 
 	t.Run("registers the receiver", func(t *testing.T) {
 		wanted := 1
-		got := len(receivers)
+		got := defaultMediator.receivers.len()
 		if wanted != got {
 			t.Errorf("wanted %d, got %d", wanted, got)
 		}
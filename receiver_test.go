@@ -3,6 +3,7 @@ package mediator
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 )
 
@@ -18,7 +19,7 @@ func TestThatTheRegistrationInterfaceRemovesTheReceiver(t *testing.T) {
 	// ACT
 
 	wanted := 1
-	got := len(receivers)
+	got := defaultMediator.receivers.len()
 	if wanted != got {
 		t.Errorf("wanted %d handlers, got %d", wanted, got)
 	}
@@ -27,7 +28,7 @@ func TestThatTheRegistrationInterfaceRemovesTheReceiver(t *testing.T) {
 	// ASSERT
 
 	wanted = 0
-	got = len(receivers)
+	got = defaultMediator.receivers.len()
 	if wanted != got {
 		t.Errorf("wanted %d handlers, got %d", wanted, got)
 	}
@@ -189,3 +190,31 @@ func TestThatReceiverIsExecutedWhenRequestValidationIsSuccessful(t *testing.T) {
 		t.Errorf("wanted %q, got \"%v\"", wanted, got)
 	}
 }
+
+func TestTryRegisterReceiver(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	existing := &mockreceiver[string]{}
+	RegisterReceiverOn[string](m, existing)
+
+	// ACT
+
+	reg, err := TryRegisterReceiverOn[string](m, &mockreceiver[string]{})
+
+	// ASSERT
+
+	if reg != nil {
+		t.Error("wanted nil registration")
+	}
+
+	wanted := DuplicateRegistrationError{}
+	if !errors.As(err, &wanted) {
+		t.Fatalf("wanted %T, got %T", wanted, err)
+	}
+	if wanted.Kind() != ReceiverKind || wanted.RequestType() != reflect.TypeOf("") || wanted.ExistingType() != reflect.TypeOf(existing) {
+		t.Errorf("wanted %v for %v (existing %v), got %v for %v (existing %v)",
+			ReceiverKind, reflect.TypeOf(""), reflect.TypeOf(existing),
+			wanted.Kind(), wanted.RequestType(), wanted.ExistingType())
+	}
+}
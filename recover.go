@@ -0,0 +1,48 @@
+package mediator
+
+import (
+	"context"
+	"reflect"
+	"runtime/debug"
+)
+
+// WithPanicRecovery configures a Mediator to recover from any panic in a
+// validator, pre- or post-processor, handler, receiver, subscriber or
+// stream handler, returning a HandlerPanicError instead of allowing the
+// panic to propagate to the caller.
+//
+// If a report func is specified (it may be nil) it is called with each
+// HandlerPanicError, e.g. to log the panic or report it to an error
+// tracking service.
+func WithPanicRecovery(report func(context.Context, HandlerPanicError)) Option {
+	return func(cfg *config) {
+		cfg.recovery = true
+		cfg.report = report
+	}
+}
+
+// recover recovers from a panic (if the Mediator is configured to do so),
+// setting the specified error to a HandlerPanicError.  recover must be
+// deferred.
+func (m *Mediator) recover(ctx context.Context, r *Registration, request interface{}, err *error) {
+	cfg := m.config()
+	if !cfg.recovery {
+		return
+	}
+
+	v := recover()
+	if v == nil {
+		return
+	}
+
+	perr := HandlerPanicError{
+		kind:        r.kind,
+		requesttype: reflect.TypeOf(request),
+		value:       v,
+		stack:       debug.Stack(),
+	}
+	if cfg.report != nil {
+		cfg.report(ctx, perr)
+	}
+	*err = perr
+}
@@ -0,0 +1,93 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPanicRecovery(t *testing.T) {
+	ctx := context.Background()
+	panicking := HandlerFunc[string, int](func(context.Context, string) (int, error) { panic("boom") })
+
+	t.Run("is not enabled by default", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		RegisterHandlerOn[string, int](m, panicking)
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("wanted panic %q, got %v", "boom", r)
+			}
+		}()
+
+		// ACT
+		_, _ = PerformOn[string, int](m, ctx, "request")
+	})
+
+	t.Run("handler panic", func(t *testing.T) {
+		// ARRANGE
+		reported := []HandlerPanicError{}
+		m := New(WithPanicRecovery(func(_ context.Context, err HandlerPanicError) {
+			reported = append(reported, err)
+		}))
+		RegisterHandlerOn[string, int](m, panicking)
+
+		// ACT
+		_, err := PerformOn[string, int](m, ctx, "request")
+
+		// ASSERT
+		perr := HandlerPanicError{}
+		if !errors.As(err, &perr) || !errors.Is(err, ErrHandlerPanic) {
+			t.Fatalf("wanted HandlerPanicError, got %T (%[1]v)", err)
+		}
+		if wanted, got := "panic in handler for 'string': boom", err.Error(); wanted != got {
+			t.Errorf("wanted %q, got %q", wanted, got)
+		}
+		if perr.Kind() != HandlerKind || perr.RequestType() != reflect.TypeOf("") || perr.Value() != "boom" {
+			t.Errorf("wanted handler, string and %q, got %v, %v and %v", "boom", perr.Kind(), perr.RequestType(), perr.Value())
+		}
+		if !strings.Contains(string(perr.Stack()), "recover_test.go") {
+			t.Errorf("wanted stack trace including the panicking func, got\n%s", perr.Stack())
+		}
+		if len(reported) != 1 {
+			t.Errorf("wanted 1 reported panic, got %d", len(reported))
+		}
+	})
+
+	t.Run("validator panic with an error value", func(t *testing.T) {
+		// ARRANGE
+		m := New(WithPanicRecovery(nil))
+		perr := errors.New("validator error")
+		RegisterReceiverOn[string](m, &mockreceiver[string]{
+			validate: func(context.Context, string) error { panic(perr) },
+		})
+
+		// ACT
+		err := SendOn(m, ctx, "data")
+
+		// ASSERT
+		if !errors.Is(err, ErrHandlerPanic) || !errors.Is(err, perr) {
+			t.Errorf("wanted HandlerPanicError wrapping %v, got %v", perr, err)
+		}
+	})
+
+	t.Run("stream handler panic", func(t *testing.T) {
+		// ARRANGE
+		m := New(WithPanicRecovery(nil))
+		RegisterStreamHandlerOn[pageRequest, int](m, &streamhandler[pageRequest, int]{
+			execute: func(context.Context, pageRequest, func(int) error) error { panic("boom") },
+		})
+
+		// ACT
+		s, _ := StreamOn[pageRequest, int](m, ctx, pageRequest{})
+		for range s.Items() {
+		}
+
+		// ASSERT
+		if !errors.Is(s.Err(), ErrHandlerPanic) {
+			t.Errorf("wanted HandlerPanicError, got %v", s.Err())
+		}
+	})
+}
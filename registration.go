@@ -0,0 +1,173 @@
+package mediator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Registration captures a registered type, the implementation registered
+// for that type and a reference to the registry in which the registration
+// for that type was recorded.
+//
+// A Registration is returned by the functions that register handlers,
+// receivers, subscribers and stream handlers and may be used to remove
+// that registration.
+type Registration struct {
+	registry       *registry
+	kind           Kind
+	registeredtype reflect.Type
+	resulttype     reflect.Type
+	implementation interface{}
+	name           string
+	behaviors      []Behavior
+	timeout        time.Duration
+	timed          bool
+	retry          *RetryPolicy
+
+	// The registration also holds funcs which validate (if the
+	// implementation is a Validator) and execute requests or data,
+	// without reference to the type parameters of the implementation.
+	// Stream handlers are executed directly; execute is nil.
+	validate func(context.Context, interface{}) error
+	execute  func(context.Context, interface{}) (interface{}, error)
+
+	// validators holds any additional validators supplied as options
+	validators []func(context.Context, interface{}) error
+
+	// pre- and post-processors implemented by the implementation (or
+	// added to the Mediator)
+	preprocess  func(context.Context, interface{}) error
+	postprocess func(context.Context, interface{}, interface{}, error)
+}
+
+// RegistrationOption is a function that configures a registration
+// when registering a handler or receiver.
+type RegistrationOption func(*Registration)
+
+// Name is a RegistrationOption that names a registration, e.g. to
+// identify the registration of a HandlerFunc or ReceiverFunc when
+// listing registrations.
+func Name(name string) RegistrationOption {
+	return func(r *Registration) {
+		r.name = name
+	}
+}
+
+// Validate is a RegistrationOption that adds a validator to a
+// registration, called after any validators added to the Mediator for
+// the registered type and before any validator implemented by the
+// registered implementation itself.
+//
+// The registered type must be assignable to the input type of the
+// validator, otherwise the option will panic.
+func Validate[TInput any](validator ValidatorFunc[TInput]) RegistrationOption {
+	return func(r *Registration) {
+		if input := typeOf[TInput](); !r.registeredtype.AssignableTo(input) {
+			panic(fmt.Errorf("validator for %v cannot validate %v", input, r.registeredtype))
+		}
+		r.validators = append(r.validators, validatorFor[TInput](validator))
+	}
+}
+
+// newReg returns a registration of the specified kind of implementation
+// for a type, configured using any options specified.
+func newReg(r *registry, kind Kind, t reflect.Type, impl interface{}, opts []RegistrationOption) *Registration {
+	rg := &Registration{
+		registry:       r,
+		kind:           kind,
+		registeredtype: t,
+		implementation: impl,
+	}
+	for _, opt := range opts {
+		opt(rg)
+	}
+	return rg
+}
+
+// register adds a registration to its registry if the type is not
+// already registered, otherwise a DuplicateRegistrationError is returned.
+func register(rg *Registration) error {
+	if existing := rg.registry.add(rg); existing != nil {
+		return DuplicateRegistrationError{
+			kind:         rg.kind,
+			requesttype:  rg.registeredtype,
+			existingtype: reflect.TypeOf(existing.implementation),
+		}
+	}
+	return nil
+}
+
+// call validates the specified request (or data) using any validators
+// for the registered type and the registered implementation (if it is a
+// Validator), then pre-processes and executes it, returning the result
+// and error after any post-processing.
+//
+// Validation and execution are subject to any applicable timeout.  If
+// configured to recover from panics, a panic is returned as a
+// HandlerPanicError.
+func (m *Mediator) call(ctx context.Context, d Dispatch, r *Registration, request interface{}) (result interface{}, err error) {
+	defer m.recover(ctx, r, request, &err)
+
+	return m.withTimeout(ctx, r, request, func(ctx context.Context) (interface{}, error) {
+		if err := m.validate(ctx, d, r, request); err != nil {
+			return nil, err
+		}
+		if err := m.preprocess(ctx, r, request); err != nil {
+			return nil, err
+		}
+
+		result, err := executeWithRetry(ctx, r, request)
+		m.postprocess(ctx, r, request, result, err)
+
+		return result, err
+	})
+}
+
+// Type returns the request (or data, or notification) type for which
+// the implementation is registered.
+func (r *Registration) Type() reflect.Type {
+	return r.registeredtype
+}
+
+// Name returns the name of the registration, if named using the Name
+// option, otherwise an empty string.
+func (r *Registration) Name() string {
+	return r.name
+}
+
+// Kind returns the kind of implementation registered.
+func (r *Registration) Kind() Kind {
+	return r.kind
+}
+
+// Implementation returns the registered implementation.
+func (r *Registration) Implementation() interface{} {
+	return r.implementation
+}
+
+// IsActive returns true if the registration has not been removed.
+//
+// A registration that has been overridden remains active (it has not
+// been removed) even though it will not be used until the override
+// is removed.
+func (r *Registration) IsActive() bool {
+	for _, rg := range r.registry.all(r.registeredtype) {
+		if rg == r {
+			return true
+		}
+	}
+	return false
+}
+
+// Remove removes the registration from the registry where it was
+// registered.
+//
+// Only this registration is removed; any other registration for the
+// same type, including one registered after this registration was
+// removed, is not affected.  Removing a registration that has already
+// been removed has no effect.
+func (r *Registration) Remove() {
+	r.registry.remove(r)
+}
@@ -0,0 +1,90 @@
+package mediator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestRegistration(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	handler := returning[string]("result")
+	receiver := &mockreceiver[int]{}
+
+	// ACT
+
+	hr := RegisterHandlerOn[string, string](m, handler)
+	rr := RegisterReceiverOn[int](m, receiver)
+
+	// ASSERT
+
+	testcases := []struct {
+		name         string
+		registration *Registration
+		kind         Kind
+		registered   reflect.Type
+		impl         interface{}
+	}{
+		{name: "handler", registration: hr, kind: HandlerKind, registered: reflect.TypeOf(""), impl: handler},
+		{name: "receiver", registration: rr, kind: ReceiverKind, registered: reflect.TypeOf(0), impl: receiver},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.registration
+			if r.Kind() != tc.kind || r.Type() != tc.registered || r.Implementation() != tc.impl {
+				t.Errorf("wanted %v for %v (%T), got %v for %v (%T)", tc.kind, tc.registered, tc.impl, r.Kind(), r.Type(), r.Implementation())
+			}
+			if !r.IsActive() {
+				t.Error("wanted active registration")
+			}
+
+			r.Remove()
+			if r.IsActive() {
+				t.Error("wanted inactive registration")
+			}
+		})
+	}
+}
+
+func TestThatRemovingARegistrationAgainDoesNotRemoveANewerRegistration(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	original := RegisterHandlerOn[string, string](m, returning[string]("original"))
+	original.Remove()
+	newer := RegisterHandlerOn[string, string](m, returning[string]("newer"))
+
+	// ACT
+
+	original.Remove()
+
+	// ASSERT
+
+	if !newer.IsActive() {
+		t.Error("newer registration was removed")
+	}
+
+	result, err := PerformOn[string, string](m, context.Background(), "request")
+	if err != nil || result != "newer" {
+		t.Errorf("wanted %q, got %q (err: %v)", "newer", result, err)
+	}
+}
+
+func TestThatAnOverriddenRegistrationIsActive(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	original := RegisterReceiverOn[string](m, &mockreceiver[string]{})
+
+	// ACT
+
+	override := OverrideReceiverOn[string](m, &mockreceiver[string]{})
+
+	// ASSERT
+
+	if !original.IsActive() || !override.IsActive() {
+		t.Errorf("wanted both registrations active, got %v and %v", original.IsActive(), override.IsActive())
+	}
+}
@@ -1,19 +1,194 @@
 package mediator
 
-import "reflect"
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
 
-var receivers = map[reflect.Type]interface{}{}
-var handlers = map[reflect.Type]interface{}{}
+// registry is a concurrency-safe map of registered types to the
+// registrations for them.
+//
+// Reads are lock-free, using the current snapshot of the map.  Writers
+// are serialised by a mutex and replace the snapshot with an updated
+// copy, so that a snapshot (including the slices of registrations it
+// holds) is never modified once it has been stored.
+type registry struct {
+	mu       sync.Mutex
+	snapshot atomic.Value // map[reflect.Type][]*Registration
 
-// reg captures a registered type and a reference to the
-// map in which the registration for that type was recorded
-type reg struct {
-	registry       map[reflect.Type]interface{}
-	registeredtype reflect.Type
+	// observe (if set) is called when a registration is added to
+	// (or removed from) the registry
+	observe func(rg *Registration, added bool)
+
+	// pending holds changes yet to be observed, in the order in which
+	// they were made; notifying is true while a goroutine is delivering
+	// them.  Both are guarded by the mutex.
+	pending   []change
+	notifying bool
+}
+
+// change is a registration added to (or removed from) a registry
+type change struct {
+	rg    *Registration
+	added bool
+}
+
+// newRegistry returns an empty registry
+func newRegistry() *registry {
+	r := &registry{}
+	r.snapshot.Store(map[reflect.Type][]*Registration{})
+	return r
+}
+
+// entries returns the current snapshot of the registry.  The returned
+// map must not be modified.
+func (r *registry) entries() map[reflect.Type][]*Registration {
+	return r.snapshot.Load().(map[reflect.Type][]*Registration)
+}
+
+// get returns the most recent registration for the specified type
+func (r *registry) get(t reflect.Type) (*Registration, bool) {
+	regs := r.entries()[t]
+	if len(regs) == 0 {
+		return nil, false
+	}
+	return regs[len(regs)-1], true
+}
+
+// all returns all registrations for the specified type, in the order
+// in which they were added.  The returned slice must not be modified.
+func (r *registry) all(t reflect.Type) []*Registration {
+	return r.entries()[t]
+}
+
+// len returns the number of types registered
+func (r *registry) len() int {
+	return len(r.entries())
+}
+
+// add adds a registration for its type if the type is not already
+// registered.  If the type is already registered the registry is not
+// changed and the existing registration is returned, otherwise nil.
+func (r *registry) add(rg *Registration) *Registration {
+	r.mu.Lock()
+	if existing := r.entries()[rg.registeredtype]; len(existing) > 0 {
+		r.mu.Unlock()
+		return existing[len(existing)-1]
+	}
+	r.update(rg.registeredtype, []*Registration{rg})
+	r.changed(rg, true)
+	r.mu.Unlock()
+
+	r.notify()
+	return nil
+}
+
+// append adds a registration for its type, in addition to any existing
+// registrations for that type.
+func (r *registry) append(rg *Registration) {
+	r.mu.Lock()
+	current := r.entries()[rg.registeredtype]
+	updated := make([]*Registration, 0, len(current)+1)
+	updated = append(updated, current...)
+	updated = append(updated, rg)
+	r.update(rg.registeredtype, updated)
+	r.changed(rg, true)
+	r.mu.Unlock()
+
+	r.notify()
+}
+
+// remove removes the specified registration.  Any other registrations
+// for the same type are not affected.
+func (r *registry) remove(rg *Registration) {
+	r.mu.Lock()
+	current := r.entries()[rg.registeredtype]
+	updated := make([]*Registration, 0, len(current))
+	for _, existing := range current {
+		if existing != rg {
+			updated = append(updated, existing)
+		}
+	}
+	if len(updated) != len(current) {
+		r.update(rg.registeredtype, updated)
+		r.changed(rg, false)
+	}
+	r.mu.Unlock()
+
+	r.notify()
+}
+
+// changed queues a change to be observed, if the registry is observed.
+//
+// The caller must hold the mutex.
+func (r *registry) changed(rg *Registration, added bool) {
+	if r.observe != nil {
+		r.pending = append(r.pending, change{rg: rg, added: added})
+	}
+}
+
+// notify calls the observe func of the registry (if any) for each
+// pending change, in the order in which the changes were made.
+//
+// Only one goroutine delivers changes at a time; if changes are already
+// being delivered (by another goroutine, or by an observer making a
+// change to the registry) notify returns immediately, leaving the
+// pending changes to be delivered by the goroutine already doing so.
+//
+// The caller must not hold the mutex.
+func (r *registry) notify() {
+	r.mu.Lock()
+	if r.notifying {
+		r.mu.Unlock()
+		return
+	}
+	r.notifying = true
+	r.mu.Unlock()
+
+	// if the observe func panics, any remaining changes are left to be
+	// delivered by the next call to notify
+	done := false
+	defer func() {
+		if !done {
+			r.mu.Lock()
+			r.notifying = false
+			r.mu.Unlock()
+		}
+	}()
+
+	for {
+		r.mu.Lock()
+		if len(r.pending) == 0 {
+			r.pending = nil
+			r.notifying = false
+			r.mu.Unlock()
+			done = true
+			return
+		}
+		c := r.pending[0]
+		r.pending = r.pending[1:]
+		r.mu.Unlock()
+
+		r.observe(c.rg, c.added)
+	}
 }
 
-// Remove removes the registration entry for the recorded type
-// from the registry where it was registered
-func (r *reg) Remove() {
-	delete(r.registry, r.registeredtype)
+// update replaces the snapshot with a copy in which the registrations
+// for the specified type are replaced with those specified (or removed
+// entirely if none are specified).
+//
+// The caller must hold the mutex.
+func (r *registry) update(t reflect.Type, regs []*Registration) {
+	current := r.entries()
+	updated := make(map[reflect.Type][]*Registration, len(current)+1)
+	for k, v := range current {
+		updated[k] = v
+	}
+	if len(regs) > 0 {
+		updated[t] = regs
+	} else {
+		delete(updated, t)
+	}
+	r.snapshot.Store(updated)
 }
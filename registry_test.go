@@ -0,0 +1,182 @@
+package mediator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	key := reflect.TypeOf("")
+
+	t.Run("adds a registration", func(t *testing.T) {
+		r := newRegistry()
+		rg := newReg(r, HandlerKind, key, "impl", nil)
+
+		existing := r.add(rg)
+
+		got, found := r.get(key)
+		if existing != nil || !found || got != rg {
+			t.Errorf("wanted %v (existing: nil, found: true), got %v (existing: %v, found: %v)", rg, got, existing, found)
+		}
+	})
+
+	t.Run("does not replace an existing registration", func(t *testing.T) {
+		r := newRegistry()
+		first := newReg(r, HandlerKind, key, "first", nil)
+		r.add(first)
+
+		existing := r.add(newReg(r, HandlerKind, key, "second", nil))
+
+		got, _ := r.get(key)
+		if existing != first || got != first {
+			t.Errorf("wanted %v (existing: %[1]v), got %v (existing: %v)", first, got, existing)
+		}
+	})
+
+	t.Run("appends registrations", func(t *testing.T) {
+		r := newRegistry()
+		first := newReg(r, HandlerKind, key, "first", nil)
+		second := newReg(r, HandlerKind, key, "second", nil)
+		r.append(first)
+
+		r.append(second)
+
+		got, _ := r.get(key)
+		if got != second || !reflect.DeepEqual(r.all(key), []*Registration{first, second}) {
+			t.Errorf("wanted %v (most recent) of %v, got %v of %v", second, []*Registration{first, second}, got, r.all(key))
+		}
+	})
+
+	t.Run("removes a registration", func(t *testing.T) {
+		r := newRegistry()
+		rg := newReg(r, HandlerKind, key, "impl", nil)
+		r.add(rg)
+
+		r.remove(rg)
+
+		if _, found := r.get(key); found || r.len() != 0 {
+			t.Error("implementation was not removed")
+		}
+	})
+
+	t.Run("removes only the specified registration", func(t *testing.T) {
+		r := newRegistry()
+		first := newReg(r, HandlerKind, key, "first", nil)
+		second := newReg(r, HandlerKind, key, "second", nil)
+		r.append(first)
+		r.append(second)
+
+		r.remove(first)
+
+		if !reflect.DeepEqual(r.all(key), []*Registration{second}) {
+			t.Errorf("wanted %v, got %v", []*Registration{second}, r.all(key))
+		}
+	})
+
+	t.Run("does not modify an existing snapshot", func(t *testing.T) {
+		r := newRegistry()
+		snapshot := r.entries()
+
+		r.add(newReg(r, HandlerKind, key, "impl", nil))
+
+		if len(snapshot) != 0 {
+			t.Error("snapshot was modified")
+		}
+	})
+}
+
+// concurrency tests; these are most useful when run with the race detector:
+//
+//	go test -race ./...
+
+type raceRequest[T any] struct{ value T }
+
+func TestConcurrentRegistrationAndDispatch(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	const workers = 8
+	const iterations = 200
+
+	// a handler and receiver which remain registered throughout
+	RegisterHandlerOn[raceRequest[int], int](m, &mockhandler[raceRequest[int], int]{
+		execute: func(_ context.Context, rq raceRequest[int]) (int, error) { return rq.value, nil },
+	})
+	RegisterReceiverOn[raceRequest[int]](m, &mockreceiver[raceRequest[int]]{
+		execute: func(context.Context, raceRequest[int]) error { return nil },
+	})
+
+	errs := make(chan error, workers*iterations*2)
+	wg := sync.WaitGroup{}
+
+	// ACT
+
+	// goroutines repeatedly registering and removing a handler and
+	// receiver for a type that is also being dispatched concurrently
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				func() {
+					defer func() { _ = recover() }() // another goroutine may hold the registration
+					r := RegisterHandlerOn[raceRequest[string], string](m, &mockhandler[raceRequest[string], string]{
+						execute: func(_ context.Context, rq raceRequest[string]) (string, error) { return rq.value, nil },
+					})
+					defer r.Remove()
+					_, _ = PerformOn[raceRequest[string], string](m, context.Background(), raceRequest[string]{"value"})
+				}()
+				func() {
+					defer func() { _ = recover() }()
+					r := RegisterReceiverOn[raceRequest[string]](m, &mockreceiver[raceRequest[string]]{
+						execute: func(context.Context, raceRequest[string]) error { return nil },
+					})
+					defer r.Remove()
+					_ = SendOn(m, context.Background(), raceRequest[string]{"value"})
+				}()
+			}
+		}()
+	}
+
+	// goroutines dispatching to the handler and receiver that remain registered
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				result, err := PerformOn[raceRequest[int], int](m, context.Background(), raceRequest[int]{i})
+				if err == nil && result != i {
+					err = fmt.Errorf("wanted %d, got %d", i, result)
+				}
+				if err != nil {
+					errs <- err
+				}
+				if err := SendOn(m, context.Background(), raceRequest[int]{i}); err != nil {
+					errs <- err
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	// ASSERT
+
+	for err := range errs {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	t.Run("all transient registrations are removed", func(t *testing.T) {
+		wanted := 1
+		if got := m.handlers.len(); got != wanted {
+			t.Errorf("wanted %d handlers, got %d", wanted, got)
+		}
+		if got := m.receivers.len(); got != wanted {
+			t.Errorf("wanted %d receivers, got %d", wanted, got)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package mediator
+
+import (
+	"reflect"
+	"sort"
+)
+
+// typeOf returns the reflect.Type of T.  Unlike reflect.TypeOf(*new(T)),
+// this returns the interface type (rather than nil) when T is an
+// interface.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// requestType returns the type of a request (or data), or T if the
+// request is a nil interface
+func requestType[T any](request T) reflect.Type {
+	if t := reflect.TypeOf(request); t != nil {
+		return t
+	}
+	return typeOf[T]()
+}
+
+// WithPolymorphicDispatch is an Option that enables polymorphic resolution
+// of the handler or receiver for requests and data by a Mediator.
+//
+// By default, a handler or receiver is resolved only by the exact type
+// of the request or data.  With polymorphic dispatch, if there is no
+// handler or receiver registered for the exact type then:
+//
+//   - for a pointer, the handler or receiver registered for the element
+//     type is used (with the value referenced by the pointer);
+//   - for a non-pointer, the handler or receiver registered for a pointer
+//     to the type is used (with a pointer to a copy of the value);
+//   - otherwise, a handler or receiver registered for an interface
+//     implemented by the type is used.
+//
+// If more than one interface registration matches then the registration
+// for the most specific interface is used, i.e. an interface which
+// itself implements all other matching interfaces.  If there is no
+// single most specific interface an AmbiguousHandlerError is returned.
+func WithPolymorphicDispatch() Option {
+	return func(cfg *config) {
+		cfg.polymorphic = true
+	}
+}
+
+// resolve returns the registration to be used for the specified request
+// (or data) from a registry, together with the request to be passed to
+// the registered implementation.
+//
+// If no registration is found, a nil registration is returned.  If more
+// than one interface registration is equally applicable, an
+// AmbiguousHandlerError is returned.
+func (m *Mediator) resolve(r *registry, request interface{}) (*Registration, interface{}, error) {
+	requesttype := reflect.TypeOf(request)
+
+	if rg, ok := r.get(requesttype); ok {
+		return rg, request, nil
+	}
+
+	if requesttype == nil || !m.config().polymorphic {
+		return nil, request, nil
+	}
+
+	// pointer -> element
+	v := reflect.ValueOf(request)
+	if requesttype.Kind() == reflect.Ptr && !v.IsNil() {
+		if rg, ok := r.get(requesttype.Elem()); ok {
+			return rg, v.Elem().Interface(), nil
+		}
+	}
+
+	// value -> pointer
+	if requesttype.Kind() != reflect.Ptr {
+		if rg, ok := r.get(reflect.PtrTo(requesttype)); ok {
+			p := reflect.New(requesttype)
+			p.Elem().Set(v)
+			return rg, p.Interface(), nil
+		}
+	}
+
+	// interfaces
+	candidates := []reflect.Type{}
+	for t := range r.entries() {
+		if t.Kind() == reflect.Interface && requesttype.Implements(t) {
+			candidates = append(candidates, t)
+		}
+	}
+	candidates = mostSpecific(candidates)
+
+	switch len(candidates) {
+	case 0:
+		return nil, request, nil
+	case 1:
+		rg, ok := r.get(candidates[0])
+		if !ok {
+			// removed since the candidates were identified
+			return nil, request, nil
+		}
+		return rg, request, nil
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].String() < candidates[j].String() })
+	return nil, request, AmbiguousHandlerError{requesttype: requesttype, candidates: candidates}
+}
+
+// mostSpecific returns those interface types from the specified types
+// which are not implemented by any other (more specific) interface in
+// the types.
+func mostSpecific(types []reflect.Type) []reflect.Type {
+	result := make([]reflect.Type, 0, len(types))
+	for _, t := range types {
+		specific := true
+		for _, other := range types {
+			if other != t && other.Implements(t) && !t.Implements(other) {
+				specific = false
+				break
+			}
+		}
+		if specific {
+			result = append(result, t)
+		}
+	}
+	return result
+}
@@ -0,0 +1,156 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type shape interface{ area() float64 }
+type polygon interface {
+	shape
+	sides() int
+}
+type named interface{ name() string }
+
+type square struct{ side float64 }
+
+func (s square) area() float64 { return s.side * s.side }
+func (s square) sides() int    { return 4 }
+func (s square) name() string  { return "square" }
+
+type circle struct{ radius float64 }
+
+func (c circle) area() float64 { return 3 * c.radius * c.radius }
+
+// returning returns a mock handler returning the specified result
+func returning[TRequest any](result string) *mockhandler[TRequest, string] {
+	return &mockhandler[TRequest, string]{execute: func(context.Context, TRequest) (string, error) { return result, nil }}
+}
+
+func TestPolymorphicDispatch(t *testing.T) {
+	t.Run("is not enabled by default", func(t *testing.T) {
+		m := New()
+		RegisterHandlerOn[square, string](m, returning[square]("square"))
+
+		_, err := PerformOn[*square, string](m, context.Background(), &square{})
+
+		if !errors.Is(err, ErrNoHandler) {
+			t.Errorf("wanted NoHandlerError, got %T (%[1]v)", err)
+		}
+	})
+
+	t.Run("prefers an exact match", func(t *testing.T) {
+		m := New(WithPolymorphicDispatch())
+		RegisterHandlerOn[square, string](m, returning[square]("value"))
+		RegisterHandlerOn[*square, string](m, returning[*square]("pointer"))
+		RegisterHandlerOn[shape, string](m, returning[shape]("shape"))
+
+		result, err := PerformOn[*square, string](m, context.Background(), &square{})
+
+		if err != nil || result != "pointer" {
+			t.Errorf("wanted %q, got %q (err: %v)", "pointer", result, err)
+		}
+	})
+
+	t.Run("falls back from pointer to element type", func(t *testing.T) {
+		m := New(WithPolymorphicDispatch())
+		mock := returning[square]("value")
+		RegisterHandlerOn[square, string](m, mock)
+
+		result, err := PerformOn[*square, string](m, context.Background(), &square{side: 2})
+
+		if err != nil || result != "value" {
+			t.Errorf("wanted %q, got %q (err: %v)", "value", result, err)
+		}
+		if requests := mock.Requests(); len(requests) != 1 || requests[0] != (square{side: 2}) {
+			t.Errorf("wanted request %v, got %v", square{side: 2}, requests)
+		}
+	})
+
+	t.Run("does not fall back from a nil pointer", func(t *testing.T) {
+		m := New(WithPolymorphicDispatch())
+		RegisterHandlerOn[square, string](m, returning[square]("value"))
+
+		_, err := PerformOn[*square, string](m, context.Background(), nil)
+
+		if !errors.Is(err, ErrNoHandler) {
+			t.Errorf("wanted NoHandlerError, got %T (%[1]v)", err)
+		}
+	})
+
+	t.Run("falls back from value to pointer type", func(t *testing.T) {
+		m := New(WithPolymorphicDispatch())
+		var got *square
+		RegisterReceiverOn[*square](m, &mockreceiver[*square]{execute: func(_ context.Context, sq *square) error {
+			got = sq
+			return nil
+		}})
+
+		err := SendOn(m, context.Background(), square{side: 3})
+
+		if err != nil || got == nil || *got != (square{side: 3}) {
+			t.Errorf("wanted &%v, got %v (err: %v)", square{side: 3}, got, err)
+		}
+	})
+
+	t.Run("falls back to an interface", func(t *testing.T) {
+		m := New(WithPolymorphicDispatch())
+		RegisterHandlerOn[shape, string](m, returning[shape]("shape"))
+
+		result, err := PerformOn[circle, string](m, context.Background(), circle{})
+
+		if err != nil || result != "shape" {
+			t.Errorf("wanted %q, got %q (err: %v)", "shape", result, err)
+		}
+	})
+
+	t.Run("prefers the most specific interface", func(t *testing.T) {
+		m := New(WithPolymorphicDispatch())
+		RegisterHandlerOn[shape, string](m, returning[shape]("shape"))
+		RegisterHandlerOn[polygon, string](m, returning[polygon]("polygon"))
+
+		result, err := PerformOn[square, string](m, context.Background(), square{})
+
+		if err != nil || result != "polygon" {
+			t.Errorf("wanted %q, got %q (err: %v)", "polygon", result, err)
+		}
+	})
+
+	t.Run("returns an error when interfaces are ambiguous", func(t *testing.T) {
+		m := New(WithPolymorphicDispatch())
+		RegisterReceiverOn[shape](m, &mockreceiver[shape]{execute: func(context.Context, shape) error { return nil }})
+		RegisterReceiverOn[named](m, &mockreceiver[named]{execute: func(context.Context, named) error { return nil }})
+
+		err := SendOn(m, context.Background(), square{})
+
+		wanted := "ambiguous handler for 'mediator.square': registered for [mediator.named mediator.shape]"
+		if !errors.As(err, &AmbiguousHandlerError{}) || err.Error() != wanted {
+			t.Errorf("wanted %q, got %v", wanted, err)
+		}
+	})
+
+	t.Run("validates using the resolved handler", func(t *testing.T) {
+		m := New(WithPolymorphicDispatch())
+		h := returning[shape]("shape")
+		h.validate = func(context.Context, shape) error { return errors.New("invalid") }
+		RegisterHandlerOn[shape, string](m, h)
+
+		_, err := PerformOn[circle, string](m, context.Background(), circle{})
+
+		if !errors.As(err, &ValidationError{}) {
+			t.Errorf("wanted ValidationError, got %T (%[1]v)", err)
+		}
+	})
+
+	t.Run("returns an error when the resolved handler returns the wrong type", func(t *testing.T) {
+		m := New(WithPolymorphicDispatch())
+		RegisterHandlerOn[shape, string](m, returning[shape]("shape"))
+
+		_, err := PerformOn[circle, int](m, context.Background(), circle{})
+
+		if !errors.Is(err, ErrInvalidHandler) {
+			t.Errorf("wanted InvalidHandlerError, got %T (%[1]v)", err)
+		}
+	})
+}
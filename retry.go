@@ -0,0 +1,171 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// NoJitter may be specified as the Jitter of a RetryPolicy to disable
+// jitter, so that the delays between attempts are deterministic.
+const NoJitter = -1
+
+// RetryPolicy configures the retrying of a handler (or receiver or
+// subscriber) that returns an error (see Retry).  Zero values are
+// replaced by the corresponding values of DefaultRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times the handler is called,
+	// including the first attempt
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between attempts
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor by which the delay is increased after
+	// each retry.  A Multiplier of 1 (or less, other than zero) disables
+	// the increase, giving a constant delay of InitialBackoff
+	Multiplier float64
+
+	// Jitter is the proportion (0 to 1) by which each delay is randomly
+	// varied, either up or down.  Since zero is replaced by the default,
+	// jitter is disabled by specifying NoJitter (or any negative value)
+	Jitter float64
+
+	// Retryable returns true if an attempt returning the specified error
+	// should be retried
+	Retryable func(error) bool
+}
+
+// DefaultRetryPolicy provides the values used for any zero values in a
+// RetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+	Retryable:      IsRetryable,
+}
+
+// IsRetryable is the default classifier of retryable errors, returning
+// true for any error other than a validation error (see ErrValidation)
+// or a context error.
+func IsRetryable(err error) bool {
+	return !errors.Is(err, ErrValidation) &&
+		!errors.Is(err, context.Canceled) &&
+		!errors.Is(err, context.DeadlineExceeded)
+}
+
+// Retry is a RegistrationOption that retries a handler (or receiver or
+// subscriber) returning a retryable error, according to the specified
+// policy:
+//
+//	mediator.RegisterHandler[GetQuote, *Quote](handler, mediator.Retry(mediator.RetryPolicy{MaxAttempts: 5}))
+//
+// Only the execution of the handler is retried; the request is validated
+// (and pre-processed) once and post-processors observe only the final
+// result and error.  Retrying stops early if the context is done, or if
+// the delay before the next attempt would exceed the context deadline.
+//
+// If an error is returned after more than one attempt, it is returned
+// as a RetryError; an error returned after only one attempt (including
+// when retrying stops early) is returned as-is.  If a timeout (see
+// Timeout) expires after more than one attempt, the RetryError wraps
+// the TimeoutError.
+func Retry(policy RetryPolicy) RegistrationOption {
+	def := DefaultRetryPolicy
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = def.MaxAttempts
+	}
+	if policy.InitialBackoff == 0 {
+		policy.InitialBackoff = def.InitialBackoff
+	}
+	if policy.MaxBackoff == 0 {
+		policy.MaxBackoff = def.MaxBackoff
+	}
+	switch {
+	case policy.Multiplier == 0:
+		policy.Multiplier = def.Multiplier
+	case policy.Multiplier < 1:
+		policy.Multiplier = 1
+	}
+	switch {
+	case policy.Jitter == 0:
+		policy.Jitter = def.Jitter
+	case policy.Jitter < 0:
+		policy.Jitter = 0
+	case policy.Jitter > 1:
+		policy.Jitter = 1
+	}
+	if policy.Retryable == nil {
+		policy.Retryable = def.Retryable
+	}
+
+	return func(r *Registration) {
+		r.retry = &policy
+	}
+}
+
+// backoff returns the delay before the specified retry (1 being the
+// first retry)
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	d := float64(p.InitialBackoff)
+	for i := 1; i < retry; i++ {
+		d *= p.Multiplier
+	}
+	if d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	d *= 1 + p.Jitter*(rand.Float64()*2-1)
+	return time.Duration(d)
+}
+
+// executeWithRetry executes a request using a registration, retrying
+// according to the retry policy of the registration (if any).
+func executeWithRetry(ctx context.Context, r *Registration, request interface{}) (interface{}, error) {
+	p := r.retry
+	if p == nil {
+		return r.execute(ctx, request)
+	}
+
+	attempts := 0
+	for {
+		attempts++
+		result, err := r.execute(ctx, request)
+		if err == nil || attempts >= p.MaxAttempts || !p.Retryable(err) || !p.wait(ctx, attempts) {
+			return result, retryError(attempts, err)
+		}
+	}
+}
+
+// wait waits for the delay before the specified retry, returning false
+// (without waiting) if the delay would exceed the deadline of the context
+// or if the context is done before the delay has elapsed.
+func (p *RetryPolicy) wait(ctx context.Context, retry int) bool {
+	delay := p.backoff(retry)
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+		return false
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// retryError returns a RetryError wrapping an error returned after more
+// than one attempt, otherwise the error itself.
+func retryError(attempts int, err error) error {
+	if err == nil || attempts < 2 {
+		return err
+	}
+	return RetryError{attempts: attempts, err: err}
+}
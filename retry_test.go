@@ -0,0 +1,246 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// failing returns a handler that fails the specified number of times
+// with the specified error before succeeding, counting the calls made
+func failing(n int, err error, calls *int) HandlerFunc[string, int] {
+	return func(ctx context.Context, _ string) (int, error) {
+		*calls++
+		if *calls <= n {
+			return 0, err
+		}
+		return *calls, nil
+	}
+}
+
+func TestRetry(t *testing.T) {
+	transient := errors.New("transient")
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	t.Run("succeeds after retrying", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		calls := 0
+		RegisterHandlerOn[string, int](m, failing(2, transient, &calls), Retry(policy))
+
+		// ACT
+		result, err := PerformOn[string, int](m, context.Background(), "request")
+
+		// ASSERT
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if result != 3 || calls != 3 {
+			t.Errorf("wanted result 3 after 3 calls, got %d after %d", result, calls)
+		}
+	})
+
+	t.Run("fails after max attempts", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		calls := 0
+		RegisterHandlerOn[string, int](m, failing(5, transient, &calls), Retry(policy))
+
+		// ACT
+		_, err := PerformOn[string, int](m, context.Background(), "request")
+
+		// ASSERT
+		rerr := RetryError{}
+		if !errors.As(err, &rerr) || !errors.Is(err, transient) {
+			t.Fatalf("wanted RetryError wrapping %v, got %T (%[2]v)", transient, err)
+		}
+		if wanted, got := "failed after 3 attempts: transient", err.Error(); wanted != got {
+			t.Errorf("wanted %q, got %q", wanted, got)
+		}
+		if rerr.Attempts() != 3 || calls != 3 {
+			t.Errorf("wanted 3 attempts, got %d (%d calls)", rerr.Attempts(), calls)
+		}
+	})
+
+	t.Run("does not retry validation errors", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		calls := 0
+		RegisterHandlerOn[string, int](m, failing(1, ValidationError{transient}, &calls), Retry(policy))
+
+		// ACT
+		_, err := PerformOn[string, int](m, context.Background(), "request")
+
+		// ASSERT
+		if !errors.Is(err, ErrValidation) || errors.As(err, &RetryError{}) {
+			t.Errorf("wanted ValidationError, got %T (%[1]v)", err)
+		}
+		if calls != 1 {
+			t.Errorf("wanted 1 call, got %d", calls)
+		}
+	})
+
+	t.Run("custom classifier", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		calls := 0
+		RegisterReceiverOn[string](m, ReceiverFunc[string](func(ctx context.Context, _ string) error {
+			calls++
+			return transient
+		}), Retry(RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			Retryable:      func(err error) bool { return false },
+		}))
+
+		// ACT
+		err := SendOn(m, context.Background(), "data")
+
+		// ASSERT
+		if err != transient || calls != 1 {
+			t.Errorf("wanted %v after 1 call, got %v after %d", transient, err, calls)
+		}
+	})
+
+	t.Run("stops when backoff exceeds deadline", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		calls := 0
+		RegisterHandlerOn[string, int](m, failing(5, transient, &calls), Retry(RetryPolicy{
+			MaxAttempts:    5,
+			InitialBackoff: time.Hour,
+		}))
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		// ACT
+		start := time.Now()
+		_, err := PerformOn[string, int](m, ctx, "request")
+
+		// ASSERT
+		if err != transient || calls != 1 {
+			t.Errorf("wanted %v after 1 call, got %T (%[2]v) after %d", transient, err, calls)
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("wanted immediate return, took %v", elapsed)
+		}
+	})
+
+	t.Run("stops when context is cancelled", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		calls := 0
+		ctx, cancel := context.WithCancel(context.Background())
+		RegisterHandlerOn[string, int](m, HandlerFunc[string, int](func(ctx context.Context, _ string) (int, error) {
+			calls++
+			cancel()
+			return 0, transient
+		}), Retry(RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour}))
+
+		// ACT
+		_, err := PerformOn[string, int](m, ctx, "request")
+
+		// ASSERT
+		if err != transient || calls != 1 {
+			t.Errorf("wanted %v after 1 call, got %v after %d", transient, err, calls)
+		}
+	})
+
+	t.Run("timeout while retrying", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		calls := 0
+		RegisterHandlerOn[string, int](m, HandlerFunc[string, int](func(ctx context.Context, _ string) (int, error) {
+			calls++
+			if calls < 3 {
+				return 0, transient
+			}
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}), Retry(RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond}), Timeout(20*time.Millisecond))
+
+		// ACT
+		_, err := PerformOn[string, int](m, context.Background(), "request")
+
+		// ASSERT
+		rerr := RetryError{}
+		if !errors.As(err, &rerr) || !errors.As(err, &TimeoutError{}) {
+			t.Fatalf("wanted RetryError wrapping TimeoutError, got %T (%[1]v)", err)
+		}
+		if rerr.Attempts() != 3 {
+			t.Errorf("wanted 3 attempts, got %d", rerr.Attempts())
+		}
+	})
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	// ARRANGE
+	p := RetryPolicy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond, Multiplier: 2, Jitter: 0.1}
+
+	testcases := []struct {
+		retry int
+		nom   time.Duration
+	}{
+		{retry: 1, nom: 10 * time.Millisecond},
+		{retry: 2, nom: 20 * time.Millisecond},
+		{retry: 3, nom: 40 * time.Millisecond},
+		{retry: 4, nom: 50 * time.Millisecond},
+	}
+	for _, tc := range testcases {
+		// ACT
+		got := p.backoff(tc.retry)
+
+		// ASSERT
+		lo, hi := tc.nom*9/10, tc.nom*11/10
+		if got < lo || got > hi {
+			t.Errorf("retry %d: wanted %v to %v, got %v", tc.retry, lo, hi, got)
+		}
+	}
+}
+
+func TestRetryPolicyDefaults(t *testing.T) {
+	testcases := []struct {
+		name       string
+		policy     RetryPolicy
+		multiplier float64
+		jitter     float64
+	}{
+		{name: "zero values", policy: RetryPolicy{}, multiplier: 2, jitter: 0.2},
+		{name: "no jitter", policy: RetryPolicy{Jitter: NoJitter}, multiplier: 2, jitter: 0},
+		{name: "negative jitter", policy: RetryPolicy{Jitter: -0.5}, multiplier: 2, jitter: 0},
+		{name: "excessive jitter", policy: RetryPolicy{Jitter: 2}, multiplier: 2, jitter: 1},
+		{name: "constant backoff", policy: RetryPolicy{Multiplier: 1}, multiplier: 1, jitter: 0.2},
+		{name: "negative multiplier", policy: RetryPolicy{Multiplier: -1}, multiplier: 1, jitter: 0.2},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			r := &Registration{}
+
+			// ACT
+			Retry(tc.policy)(r)
+
+			// ASSERT
+			if r.retry.Multiplier != tc.multiplier || r.retry.Jitter != tc.jitter {
+				t.Errorf("wanted multiplier %v and jitter %v, got %v and %v", tc.multiplier, tc.jitter, r.retry.Multiplier, r.retry.Jitter)
+			}
+		})
+	}
+
+	t.Run("deterministic backoff", func(t *testing.T) {
+		// ARRANGE
+		r := &Registration{}
+		Retry(RetryPolicy{InitialBackoff: 10 * time.Millisecond, Multiplier: 1, Jitter: NoJitter})(r)
+
+		// ACT
+		got := []time.Duration{r.retry.backoff(1), r.retry.backoff(2), r.retry.backoff(3)}
+
+		// ASSERT
+		for i, d := range got {
+			if d != 10*time.Millisecond {
+				t.Errorf("retry %d: wanted %v, got %v", i+1, 10*time.Millisecond, d)
+			}
+		}
+	})
+}
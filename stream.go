@@ -0,0 +1,153 @@
+package mediator
+
+import (
+	"context"
+	"reflect"
+)
+
+// ItemStream[TItem] is a stream of items yielded by a stream handler
+// in response to a request.
+//
+// Items are received from the channel returned by Items(), which is
+// closed when the handler has returned.  Any error returned by the
+// handler is then available from Err().
+//
+// If the caller stops receiving items before the channel is closed,
+// Close() must be called to release the handler (or the context
+// passed to Stream cancelled).
+type ItemStream[TItem any] struct {
+	items  chan TItem
+	done   chan struct{}
+	cancel context.CancelFunc
+	err    error
+}
+
+// Items returns the channel from which items yielded by the stream
+// handler are received.  The channel is closed when the handler
+// has returned.
+func (s *ItemStream[TItem]) Items() <-chan TItem {
+	return s.items
+}
+
+// Err waits for the stream to end, returning any error from the stream
+// handler (or from validating the request).
+//
+// Err must not be called before all items have been received from the
+// stream, or the stream has been closed.
+func (s *ItemStream[TItem]) Err() error {
+	<-s.done
+	return s.err
+}
+
+// Close cancels the context of the stream handler, discarding any
+// further items, and waits for the handler to return.
+func (s *ItemStream[TItem]) Close() {
+	s.cancel()
+	for range s.items {
+	}
+	<-s.done
+}
+
+// RegisterStreamHandler registers a stream handler for the specified
+// request type yielding the specified item type with the default Mediator.
+//
+// If a stream handler is already registered for the request type, the
+// function will panic with a DuplicateRegistrationError, otherwise the
+// stream handler is registered.
+func RegisterStreamHandler[TRequest any, TItem any](handler StreamHandler[TRequest, TItem], opts ...RegistrationOption) *Registration {
+	return RegisterStreamHandlerOn[TRequest, TItem](defaultMediator, handler, opts...)
+}
+
+// RegisterStreamHandlerOn registers a stream handler for the specified
+// request type yielding the specified item type with the specified Mediator.
+//
+// If a stream handler is already registered with the Mediator for the
+// request type, the function will panic with a DuplicateRegistrationError,
+// otherwise the stream handler is registered.
+func RegisterStreamHandlerOn[TRequest any, TItem any](m *Mediator, handler StreamHandler[TRequest, TItem], opts ...RegistrationOption) *Registration {
+	r := newReg(m.streams, StreamHandlerKind, typeOf[TRequest](), handler, opts)
+	r.resulttype = typeOf[TItem]()
+	r.validate = validatorFor[TRequest](handler)
+	if err := register(r); err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// Stream sends the specified request and context to the stream handler
+// registered with the default Mediator for the request type, returning
+// an ItemStream from which the items yielded by the handler are received.
+//
+// The request is passed through any behaviors added to the Mediator
+// or to the stream handler registration, then validated (if the handler
+// implements Validator) before the handler is called.  Since items are
+// streamed as they are yielded, any result returned from a behavior is
+// ignored; errors from a behavior, validation or the handler itself are
+// returned by the Err() function of the ItemStream.
+//
+// An error is returned by Stream only if there is no stream handler
+// registered for the request type, or the registered stream handler
+// does not yield the specified item type.
+func Stream[TRequest any, TItem any](ctx context.Context, request TRequest) (*ItemStream[TItem], error) {
+	return StreamOn[TRequest, TItem](defaultMediator, ctx, request)
+}
+
+// StreamOn sends the specified request and context to the stream handler
+// registered with the specified Mediator for the request type, returning
+// an ItemStream from which the items yielded by the handler are received.
+//
+// The request is processed in the same way as for Stream.
+func StreamOn[TRequest any, TItem any](m *Mediator, ctx context.Context, request TRequest) (*ItemStream[TItem], error) {
+	requesttype := reflect.TypeOf(request)
+
+	reg, ok := m.streams.get(requesttype)
+	if !ok {
+		m.observeNoHandler(ctx, StreamHandlerKind, request)
+		return nil, NoHandlerError{requesttype: reflect.TypeOf(request)}
+	}
+
+	handler, ok := reg.implementation.(StreamHandler[TRequest, TItem])
+	if !ok {
+		return nil, InvalidHandlerError{
+			handlertype: reflect.TypeOf(reg.implementation),
+			requesttype: reflect.TypeOf(request),
+			resulttype:  typeOf[TItem](),
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s := &ItemStream[TItem]{
+		items:  make(chan TItem),
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+
+	d := Dispatch{Kind: StreamHandlerKind, Type: requesttype, Request: request}
+	go func() {
+		defer close(s.done)
+		defer close(s.items)
+		defer cancel()
+
+		_, s.err = m.dispatch(ctx, d, reg, func(ctx context.Context) (_ interface{}, err error) {
+			defer m.recover(ctx, reg, request, &err)
+
+			return m.withTimeout(ctx, reg, request, func(ctx context.Context) (interface{}, error) {
+				if err := m.validate(ctx, d, reg, request); err != nil {
+					return nil, err
+				}
+
+				return nil, handler.Execute(ctx, request, func(item TItem) error {
+					select {
+					case s.items <- item:
+						return nil
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				})
+			})
+		})
+	}()
+
+	return s, nil
+}
@@ -0,0 +1,241 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type pageRequest struct {
+	pages int
+}
+
+type streamhandler[TRequest any, TItem any] struct {
+	execute  func(context.Context, TRequest, func(TItem) error) error
+	validate func(context.Context, TRequest) error
+}
+
+func (h *streamhandler[TRequest, TItem]) Execute(ctx context.Context, rq TRequest, yield func(TItem) error) error {
+	return h.execute(ctx, rq, yield)
+}
+
+func (h *streamhandler[TRequest, TItem]) Validate(ctx context.Context, rq TRequest) error {
+	if h.validate != nil {
+		return h.validate(ctx, rq)
+	}
+	return nil
+}
+
+// pager returns a stream handler yielding the page numbers from
+// 1 to the number of pages requested
+func pager() *streamhandler[pageRequest, int] {
+	return &streamhandler[pageRequest, int]{execute: func(_ context.Context, rq pageRequest, yield func(int) error) error {
+		for i := 1; i <= rq.pages; i++ {
+			if err := yield(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+}
+
+func TestThatStreamYieldsItems(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	RegisterStreamHandlerOn[pageRequest, int](m, pager())
+
+	// ACT
+
+	s, err := StreamOn[pageRequest, int](m, context.Background(), pageRequest{pages: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := []int{}
+	for item := range s.Items() {
+		got = append(got, item)
+	}
+
+	// ASSERT
+
+	wanted := []int{1, 2, 3}
+	if !reflect.DeepEqual(wanted, got) {
+		t.Errorf("wanted %v, got %v", wanted, got)
+	}
+	if err := s.Err(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestThatStreamReturnsTheTerminalError(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	failed := errors.New("failed")
+	RegisterStreamHandlerOn[pageRequest, int](m, &streamhandler[pageRequest, int]{
+		execute: func(_ context.Context, _ pageRequest, yield func(int) error) error {
+			_ = yield(1)
+			return failed
+		},
+	})
+
+	// ACT
+
+	s, _ := StreamOn[pageRequest, int](m, context.Background(), pageRequest{})
+	got := []int{}
+	for item := range s.Items() {
+		got = append(got, item)
+	}
+
+	// ASSERT
+
+	if !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("wanted %v, got %v", []int{1}, got)
+	}
+	if err := s.Err(); err != failed {
+		t.Errorf("wanted %v, got %v", failed, err)
+	}
+}
+
+func TestThatStreamValidatesTheRequestBeforeTheFirstItem(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	h := pager()
+	h.validate = func(context.Context, pageRequest) error { return errors.New("invalid") }
+	RegisterStreamHandlerOn[pageRequest, int](m, h)
+
+	// ACT
+
+	s, _ := StreamOn[pageRequest, int](m, context.Background(), pageRequest{pages: 3})
+	got := 0
+	for range s.Items() {
+		got++
+	}
+
+	// ASSERT
+
+	if got != 0 {
+		t.Errorf("wanted no items, got %d", got)
+	}
+	if err := s.Err(); !errors.As(err, &ValidationError{}) {
+		t.Errorf("wanted ValidationError, got %T (%[1]v)", err)
+	}
+}
+
+func TestThatClosingAStreamCancelsTheHandler(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	RegisterStreamHandlerOn[pageRequest, int](m, pager())
+	s, _ := StreamOn[pageRequest, int](m, context.Background(), pageRequest{pages: 1000})
+
+	// ACT
+
+	<-s.Items()
+	s.Close()
+
+	// ASSERT
+
+	if err := s.Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("wanted %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestThatCancellingTheContextCancelsTheStream(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	RegisterStreamHandlerOn[pageRequest, int](m, pager())
+	ctx, cancel := context.WithCancel(context.Background())
+	s, _ := StreamOn[pageRequest, int](m, ctx, pageRequest{pages: 1000})
+
+	// ACT
+
+	<-s.Items()
+	cancel()
+	for range s.Items() {
+	}
+
+	// ASSERT
+
+	if err := s.Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("wanted %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestThatStreamReturnsAnErrorWhenNoHandlerIsRegistered(t *testing.T) {
+	// ACT
+
+	s, err := StreamOn[pageRequest, int](New(), context.Background(), pageRequest{})
+
+	// ASSERT
+
+	if !errors.As(err, &NoHandlerError{}) || s != nil {
+		t.Errorf("wanted NoHandlerError (no stream), got %T (stream %v)", err, s)
+	}
+}
+
+func TestThatStreamReturnsAnErrorWhenTheHandlerYieldsTheWrongType(t *testing.T) {
+	// ARRANGE
+
+	m := New()
+	RegisterStreamHandlerOn[pageRequest, int](m, pager())
+
+	// ACT
+
+	s, err := StreamOn[pageRequest, string](m, context.Background(), pageRequest{})
+
+	// ASSERT
+
+	if !errors.As(err, &InvalidHandlerError{}) || s != nil {
+		t.Errorf("wanted InvalidHandlerError (no stream), got %T (stream %v)", err, s)
+	}
+}
+
+func TestThatRegisterStreamHandlerPanicsWhenAHandlerIsAlreadyRegistered(t *testing.T) {
+	// ARRANGE
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("did not panic")
+		}
+	}()
+	m := New()
+	RegisterStreamHandlerOn[pageRequest, int](m, pager())
+
+	// ACT
+
+	RegisterStreamHandlerOn[pageRequest, int](m, pager())
+
+	// ASSERT (deferred, see above)
+}
+
+func TestThatStreamsAreDispatchedThroughBehaviors(t *testing.T) {
+	// ARRANGE
+
+	var got Dispatch
+	m := New(WithBehaviors(BehaviorFunc(func(ctx context.Context, d Dispatch, next Next) (interface{}, error) {
+		got = d
+		return next(ctx)
+	})))
+	RegisterStreamHandlerOn[pageRequest, int](m, pager())
+
+	// ACT
+
+	s, _ := StreamOn[pageRequest, int](m, context.Background(), pageRequest{pages: 1})
+	for range s.Items() {
+	}
+	err := s.Err()
+
+	// ASSERT
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Kind != StreamHandlerKind {
+		t.Errorf("wanted %v, got %v", StreamHandlerKind, got.Kind)
+	}
+}
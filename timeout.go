@@ -0,0 +1,70 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"time"
+)
+
+// WithDefaultTimeout configures a Mediator with a timeout applied to the
+// validation and execution of every request (or data, or notification),
+// unless a different timeout is specified when registering the handler
+// (or receiver etc) for the type (see Timeout).  For a stream handler
+// the timeout applies to the stream as a whole.
+//
+// The timeout never extends a deadline already set on the context of the
+// caller; if the deadline of the caller is sooner, it applies.
+func WithDefaultTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.timeout = timeout
+	}
+}
+
+// Timeout is a RegistrationOption that specifies the timeout applied to
+// the validation and execution of requests (or data, or notifications)
+// by the registered handler (or receiver etc), overriding any default
+// timeout configured for the Mediator.  A timeout of zero (or less)
+// disables any default timeout for the registration.
+func Timeout(timeout time.Duration) RegistrationOption {
+	return func(r *Registration) {
+		r.timeout = timeout
+		r.timed = true
+	}
+}
+
+// withTimeout calls the specified func with a context bound by the
+// timeout applicable to a registration, if any.
+//
+// If the func returns an error after the timeout has expired (and the
+// context of the caller is not itself done) a TimeoutError is returned.
+// If the func returns successfully its result is returned, even if the
+// timeout has expired.
+func (m *Mediator) withTimeout(ctx context.Context, r *Registration, request interface{}, fn func(context.Context) (interface{}, error)) (interface{}, error) {
+	timeout := m.config().timeout
+	if r.timed {
+		timeout = r.timeout
+	}
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := fn(tctx)
+	if err != nil && ctx.Err() == nil && errors.Is(tctx.Err(), context.DeadlineExceeded) {
+		terr := TimeoutError{
+			kind:        r.kind,
+			requesttype: reflect.TypeOf(request),
+			timeout:     timeout,
+		}
+
+		// preserve the number of attempts made by a retrying registration
+		if rerr, ok := err.(RetryError); ok {
+			return result, RetryError{attempts: rerr.attempts, err: terr}
+		}
+		return result, terr
+	}
+	return result, err
+}
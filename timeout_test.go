@@ -0,0 +1,149 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// blocking returns a handler that blocks until the context is done,
+// recording the deadline of the context
+func blocking(deadline *time.Time) HandlerFunc[string, int] {
+	return func(ctx context.Context, _ string) (int, error) {
+		*deadline, _ = ctx.Deadline()
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}
+}
+
+func TestTimeouts(t *testing.T) {
+	t.Run("no timeout by default", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		var hasDeadline bool
+		RegisterHandlerOn[string, int](m, HandlerFunc[string, int](func(ctx context.Context, _ string) (int, error) {
+			_, hasDeadline = ctx.Deadline()
+			return 0, nil
+		}))
+
+		// ACT
+		_, _ = PerformOn[string, int](m, context.Background(), "request")
+
+		// ASSERT
+		if hasDeadline {
+			t.Error("wanted no deadline")
+		}
+	})
+
+	t.Run("default timeout", func(t *testing.T) {
+		// ARRANGE
+		m := New(WithDefaultTimeout(10 * time.Millisecond))
+		var deadline time.Time
+		RegisterHandlerOn[string, int](m, blocking(&deadline))
+
+		// ACT
+		_, err := PerformOn[string, int](m, context.Background(), "request")
+
+		// ASSERT
+		terr := TimeoutError{}
+		if !errors.As(err, &terr) || !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("wanted TimeoutError, got %T (%[1]v)", err)
+		}
+		if wanted, got := "handler for 'string' timed out after 10ms", err.Error(); wanted != got {
+			t.Errorf("wanted %q, got %q", wanted, got)
+		}
+		if terr.Kind() != HandlerKind || terr.RequestType() != reflect.TypeOf("") || terr.Timeout() != 10*time.Millisecond {
+			t.Errorf("unexpected error details: %v, %v, %v", terr.Kind(), terr.RequestType(), terr.Timeout())
+		}
+		if deadline.IsZero() {
+			t.Error("wanted handler context with a deadline")
+		}
+	})
+
+	t.Run("registration timeout", func(t *testing.T) {
+		// ARRANGE
+		m := New(WithDefaultTimeout(time.Hour))
+		calls := 0
+		RegisterReceiverOn[string](m, &mockreceiver[string]{
+			validate: func(ctx context.Context, _ string) error {
+				calls++
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		}, Timeout(10*time.Millisecond))
+
+		// ACT
+		err := SendOn(m, context.Background(), "data")
+
+		// ASSERT
+		terr := TimeoutError{}
+		if !errors.As(err, &terr) || terr.Timeout() != 10*time.Millisecond {
+			t.Errorf("wanted TimeoutError after 10ms, got %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("wanted validator to be called once, got %d", calls)
+		}
+	})
+
+	t.Run("registration with no timeout", func(t *testing.T) {
+		// ARRANGE
+		m := New(WithDefaultTimeout(time.Millisecond))
+		var hasDeadline bool
+		RegisterHandlerOn[string, int](m, HandlerFunc[string, int](func(ctx context.Context, _ string) (int, error) {
+			_, hasDeadline = ctx.Deadline()
+			return 0, nil
+		}), Timeout(0))
+
+		// ACT
+		_, _ = PerformOn[string, int](m, context.Background(), "request")
+
+		// ASSERT
+		if hasDeadline {
+			t.Error("wanted no deadline")
+		}
+	})
+
+	t.Run("caller deadline is sooner", func(t *testing.T) {
+		// ARRANGE
+		m := New(WithDefaultTimeout(time.Hour))
+		var deadline time.Time
+		RegisterHandlerOn[string, int](m, blocking(&deadline))
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		callerDeadline, _ := ctx.Deadline()
+
+		// ACT
+		_, err := PerformOn[string, int](m, ctx, "request")
+
+		// ASSERT
+		if !errors.Is(err, context.DeadlineExceeded) || errors.As(err, &TimeoutError{}) {
+			t.Errorf("wanted context.DeadlineExceeded (not a TimeoutError), got %T (%[1]v)", err)
+		}
+		if !deadline.Equal(callerDeadline) {
+			t.Errorf("wanted deadline %v, got %v", callerDeadline, deadline)
+		}
+	})
+
+	t.Run("timeout is sooner than the caller deadline", func(t *testing.T) {
+		// ARRANGE
+		m := New(WithDefaultTimeout(10 * time.Millisecond))
+		var deadline time.Time
+		RegisterHandlerOn[string, int](m, blocking(&deadline))
+		ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+		defer cancel()
+		callerDeadline, _ := ctx.Deadline()
+
+		// ACT
+		_, err := PerformOn[string, int](m, ctx, "request")
+
+		// ASSERT
+		if !errors.As(err, &TimeoutError{}) {
+			t.Errorf("wanted TimeoutError, got %T (%[1]v)", err)
+		}
+		if !deadline.Before(callerDeadline) {
+			t.Errorf("wanted deadline before %v, got %v", callerDeadline, deadline)
+		}
+	})
+}
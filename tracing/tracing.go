@@ -0,0 +1,108 @@
+// Package tracing provides a mediator Behavior that traces the dispatch
+// of requests, data and notifications using OpenTelemetry.
+package tracing
+
+import (
+	"context"
+
+	"github.com/blugnu/go-mediator"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer used to create spans.
+const TracerName = "github.com/blugnu/go-mediator/tracing"
+
+// Attribute keys set on the spans created by the Behavior.
+const (
+	// KindKey identifies the kind of implementation to which a request
+	// is dispatched, e.g. "handler" or "receiver".
+	KindKey = attribute.Key("mediator.kind")
+
+	// RequestTypeKey identifies the type of the request (or data, or
+	// notification) dispatched.
+	RequestTypeKey = attribute.Key("mediator.request.type")
+
+	// ErrorKey identifies the kind of error returned, if any: either
+	// "validation" (for a ValidationError) or "execution" (any other
+	// error).
+	ErrorKey = attribute.Key("mediator.error")
+)
+
+// Values of the ErrorKey attribute.
+const (
+	ValidationError = "validation"
+	ExecutionError  = "execution"
+)
+
+// Option configures the Behavior.
+type Option func(*config)
+
+type config struct {
+	provider trace.TracerProvider
+}
+
+// WithTracerProvider specifies the TracerProvider used to create spans.
+// If not specified, the global TracerProvider is used.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(cfg *config) {
+		cfg.provider = provider
+	}
+}
+
+// Behavior returns a mediator.Behavior that starts a span for each
+// request (or data, or notification) dispatched, named after the request
+// type.  The context carrying the span is passed on through the pipeline
+// so that it is available to validators and to the handler (or receiver
+// etc) itself.
+//
+// If an error is returned the error is recorded on the span, the status
+// of the span set to codes.Error and the ErrorKey attribute set to
+// distinguish a ValidationError from an error in execution.
+//
+// The Behavior should usually be the first (outermost) behavior added
+// to a Mediator:
+//
+//	mediator.Use(tracing.Behavior())
+func Behavior(opts ...Option) mediator.Behavior {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	// if no provider is specified, the global provider is obtained for
+	// each span, so that the Behavior may be added before the global
+	// provider is configured
+	tracer := func() trace.Tracer {
+		if cfg.provider != nil {
+			return cfg.provider.Tracer(TracerName)
+		}
+		return otel.GetTracerProvider().Tracer(TracerName)
+	}
+
+	return mediator.BehaviorFunc(func(ctx context.Context, d mediator.Dispatch, next mediator.Next) (interface{}, error) {
+		ctx, span := tracer().Start(ctx, d.Type.String(),
+			trace.WithSpanKind(trace.SpanKindInternal),
+			trace.WithAttributes(
+				KindKey.String(d.Kind.String()),
+				RequestTypeKey.String(d.Type.String()),
+			),
+		)
+		defer span.End()
+
+		result, err := next(ctx)
+		if err != nil {
+			kind := ExecutionError
+			if mediator.OutcomeOf(err) == mediator.OutcomeValidationError {
+				kind = ValidationError
+			}
+			span.SetAttributes(ErrorKey.String(kind))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		return result, err
+	})
+}
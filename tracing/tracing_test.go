@@ -0,0 +1,125 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/blugnu/go-mediator"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type request struct{}
+
+type data struct{}
+
+// attr returns the value of the specified attribute of a span, or an
+// empty string if the attribute is not set
+func attr(span sdktrace.ReadOnlySpan, key attribute.Key) string {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestBehavior(t *testing.T) {
+	// ARRANGE
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	m := mediator.New(mediator.WithBehaviors(Behavior(WithTracerProvider(provider))))
+
+	ctx := context.Background()
+
+	t.Run("handler", func(t *testing.T) {
+		// ARRANGE
+		defer exporter.Reset()
+		var handlerSpan, validatorSpan trace.SpanContext
+		mediator.HandleOn(m,
+			func(ctx context.Context, _ request) (string, error) {
+				handlerSpan = trace.SpanContextFromContext(ctx)
+				return "result", nil
+			},
+			mediator.Validate(func(ctx context.Context, _ request) error {
+				validatorSpan = trace.SpanContextFromContext(ctx)
+				return nil
+			}),
+		)
+
+		// ACT
+		_, err := mediator.PerformOn[request, string](m, ctx, request{})
+
+		// ASSERT
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		spans := exporter.GetSpans().Snapshots()
+		if len(spans) != 1 {
+			t.Fatalf("wanted 1 span, got %d", len(spans))
+		}
+		span := spans[0]
+		if wanted, got := "tracing.request", span.Name(); wanted != got {
+			t.Errorf("wanted span %q, got %q", wanted, got)
+		}
+		if wanted, got := "handler", attr(span, KindKey); wanted != got {
+			t.Errorf("wanted kind %q, got %q", wanted, got)
+		}
+		if wanted, got := "tracing.request", attr(span, RequestTypeKey); wanted != got {
+			t.Errorf("wanted request type %q, got %q", wanted, got)
+		}
+		if span.Status().Code != codes.Unset {
+			t.Errorf("wanted status Unset, got %v", span.Status().Code)
+		}
+		if handlerSpan.SpanID() != span.SpanContext().SpanID() || validatorSpan.SpanID() != span.SpanContext().SpanID() {
+			t.Error("span was not propagated to the validator and handler")
+		}
+	})
+
+	testcases := []struct {
+		name   string
+		err    error
+		wanted string
+	}{
+		{name: "validation error", err: mediator.ValidationError{}, wanted: ValidationError},
+		{name: "execution error", err: errors.New("execution error"), wanted: ExecutionError},
+		{name: "wrapped validation error", err: fmt.Errorf("nested: %w", mediator.ValidationError{}), wanted: ValidationError},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ARRANGE
+			defer exporter.Reset()
+			reg := mediator.RegisterReceiverOn[data](m, mediator.ReceiverFunc[data](func(context.Context, data) error {
+				return tc.err
+			}))
+			defer reg.Remove()
+
+			// ACT
+			_ = mediator.SendOn(m, ctx, data{})
+
+			// ASSERT
+			spans := exporter.GetSpans().Snapshots()
+			if len(spans) != 1 {
+				t.Fatalf("wanted 1 span, got %d", len(spans))
+			}
+			span := spans[0]
+			if wanted, got := "receiver", attr(span, KindKey); wanted != got {
+				t.Errorf("wanted kind %q, got %q", wanted, got)
+			}
+			if wanted, got := tc.wanted, attr(span, ErrorKey); wanted != got {
+				t.Errorf("wanted error %q, got %q", wanted, got)
+			}
+			if span.Status().Code != codes.Error {
+				t.Errorf("wanted status Error, got %v", span.Status().Code)
+			}
+			if len(span.Events()) != 1 || span.Events()[0].Name != "exception" {
+				t.Errorf("wanted error to be recorded, got events %v", span.Events())
+			}
+		})
+	}
+}
@@ -1,11 +1,37 @@
 package mediator
 
-import "context"
+import (
+	"context"
+	"fmt"
+)
 
+// ReceiverFunc is a func that implements Receiver.
 type ReceiverFunc[TData any] func(context.Context, TData) error
+
+// HandlerFunc is a func that implements Handler.
 type HandlerFunc[TRequest any, TResult any] func(context.Context, TRequest) (TResult, error)
+
+// ValidatorFunc is a func that implements Validator.
 type ValidatorFunc[TInput any] func(context.Context, TInput) error
 
+// Execute calls the func, enabling a ReceiverFunc to be used as a
+// Receiver.
+func (fn ReceiverFunc[TData]) Execute(ctx context.Context, data TData) error {
+	return fn(ctx, data)
+}
+
+// Execute calls the func, enabling a HandlerFunc to be used as a
+// Handler.
+func (fn HandlerFunc[TRequest, TResult]) Execute(ctx context.Context, request TRequest) (TResult, error) {
+	return fn(ctx, request)
+}
+
+// Validate calls the func, enabling a ValidatorFunc to be used as a
+// Validator.
+func (fn ValidatorFunc[TInput]) Validate(ctx context.Context, input TInput) error {
+	return fn(ctx, input)
+}
+
 // Receiver[TData] is the interface to be implemented by a receiver
 type Receiver[TData any] interface {
 	Execute(context.Context, TData) error
@@ -16,9 +42,77 @@ type Handler[TRequest any, TResult any] interface {
 	Execute(context.Context, TRequest) (TResult, error)
 }
 
+// StreamHandler[TRequest, TItem] is the interface to be implemented by
+// a handler that returns a stream of items in response to a request.
+//
+// A stream handler yields each item by calling the yield func provided.
+// If the yield func returns an error (because the stream has been closed
+// or the context cancelled) the handler should stop yielding items and
+// return that error.
+type StreamHandler[TRequest any, TItem any] interface {
+	Execute(ctx context.Context, request TRequest, yield func(TItem) error) error
+}
+
+// Subscriber[TNotification] is the interface to be implemented by a
+// subscriber to notifications.  Any number of subscribers may be
+// subscribed to notifications of the same type.
+type Subscriber[TNotification any] interface {
+	Execute(context.Context, TNotification) error
+}
+
 // Validator[TInput] is an optional interface that may be implemented
-// by both receivers and handlers, to separate the validation of the data
+// by receivers, handlers and subscribers, to separate the validation of the data
 // or request (the input) from the execution the receiver or handler itself.
 type Validator[TInput any] interface {
 	Validate(context.Context, TInput) error
 }
+
+// Kind identifies the kind of implementation registered for, or
+// dispatched to, for a request or data type.
+type Kind int
+
+const (
+	// HandlerKind identifies a Handler
+	HandlerKind Kind = iota + 1
+
+	// ReceiverKind identifies a Receiver
+	ReceiverKind
+
+	// SubscriberKind identifies a Subscriber
+	SubscriberKind
+
+	// StreamHandlerKind identifies a StreamHandler
+	StreamHandlerKind
+
+	// ValidatorKind identifies a Validator added independently of a
+	// handler, receiver, subscriber or stream handler
+	ValidatorKind
+
+	// PreProcessorKind identifies a PreProcessor added independently
+	// of a handler, receiver or subscriber
+	PreProcessorKind
+
+	// PostProcessorKind identifies a PostProcessor added independently
+	// of a handler
+	PostProcessorKind
+)
+
+func (k Kind) String() string {
+	switch k {
+	case HandlerKind:
+		return "handler"
+	case ReceiverKind:
+		return "receiver"
+	case SubscriberKind:
+		return "subscriber"
+	case StreamHandlerKind:
+		return "stream handler"
+	case ValidatorKind:
+		return "validator"
+	case PreProcessorKind:
+		return "pre-processor"
+	case PostProcessorKind:
+		return "post-processor"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
@@ -5,6 +5,103 @@ import (
 	"errors"
 )
 
+// WithAggregateValidation configures a Mediator to call all validators
+// for a request (or data), rather than stopping at the first validator
+// that returns an error.
+//
+// The errors returned by the validators are aggregated in a single
+// ValidationError with Violations combining the Violations from each
+// error.  An error that does not provide Violations is added as a
+// Violation with the error message and no path or code.
+func WithAggregateValidation() Option {
+	return func(cfg *config) {
+		cfg.aggregate = true
+	}
+}
+
+// AddValidator adds a validator for the specified input type to the
+// default Mediator.
+//
+// Any number of validators may be added for a type, independently of
+// any handler, receiver, subscriber or stream handler for that type.
+// Validators are called in the order in which they were added, before
+// any validator implemented by the handler (or receiver etc) itself.
+//
+// The returned Registration may be used to remove the validator.
+func AddValidator[TInput any](validator Validator[TInput]) *Registration {
+	return AddValidatorOn[TInput](defaultMediator, validator)
+}
+
+// AddValidatorOn adds a validator for the specified input type to the
+// specified Mediator, in the same way as AddValidator.
+func AddValidatorOn[TInput any](m *Mediator, validator Validator[TInput]) *Registration {
+	r := newReg(m.validators, ValidatorKind, typeOf[TInput](), validator, nil)
+	r.validate = validatorFor[TInput](validator)
+	m.validators.append(r)
+
+	return r
+}
+
+// validate validates a request (or data) for a registration, using any
+// validators added for the registered type, then any validators added
+// to the registration, followed by the validator implemented by the
+// registered implementation (if any).
+//
+// Unless configured to aggregate validation errors, the first error
+// returned by a validator is returned and no further validators are
+// called.  Any observers are notified of a validation failure, with the
+// Dispatch of the request (or data) being performed.
+func (m *Mediator) validate(ctx context.Context, d Dispatch, r *Registration, request interface{}) error {
+	err := m.runValidators(ctx, r, request)
+	if err != nil {
+		for _, o := range m.config().observers {
+			o.OnValidationFailure(ctx, d, err)
+		}
+	}
+	return err
+}
+
+// runValidators calls the validators for a registration (see validate)
+func (m *Mediator) runValidators(ctx context.Context, r *Registration, request interface{}) error {
+	validators := make([]func(context.Context, interface{}) error, 0, 1)
+	for _, v := range m.validators.all(r.registeredtype) {
+		validators = append(validators, v.validate)
+	}
+	validators = append(validators, r.validators...)
+	if r.validate != nil {
+		validators = append(validators, r.validate)
+	}
+
+	if !m.config().aggregate {
+		for _, v := range validators {
+			if err := v(ctx, request); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	violations := Violations{}
+	for _, v := range validators {
+		err := v(ctx, request)
+		if err == nil {
+			continue
+		}
+
+		verr := ValidationError{}
+		errors.As(err, &verr)
+		switch {
+		case len(verr.Violations()) > 0:
+			violations = append(violations, verr.Violations()...)
+		case verr.error != nil:
+			violations = append(violations, Violation{Message: verr.error.Error()})
+		default:
+			violations = append(violations, Violation{Message: err.Error()})
+		}
+	}
+	return violations.Err()
+}
+
 // validate calls the supplied Validator for the context and input specified,
 // wrapping an any returned error that is not a ValidationError.
 func validate[TInput any](v Validator[TInput], ctx context.Context, input TInput) error {
@@ -16,3 +113,16 @@ func validate[TInput any](v Validator[TInput], ctx context.Context, input TInput
 	}
 	return nil
 }
+
+// validatorFor returns a func that validates an input using the specified
+// implementation, if it implements Validator[TInput].  If the implementation
+// does not implement Validator[TInput], nil is returned.
+func validatorFor[TInput any](impl interface{}) func(context.Context, interface{}) error {
+	v, ok := impl.(Validator[TInput])
+	if !ok {
+		return nil
+	}
+	return func(ctx context.Context, input interface{}) error {
+		return validate(v, ctx, input.(TInput))
+	}
+}
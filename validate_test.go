@@ -0,0 +1,153 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// accepting returns a mock receiver that accepts any data
+func accepting[TData any]() *mockreceiver[TData] {
+	return &mockreceiver[TData]{execute: func(context.Context, TData) error { return nil }}
+}
+
+// violation returns a ValidatorFunc returning a Violation with the
+// specified path, recording the path in the specified slice when called
+func violation[TInput any](path string, calls *[]string) ValidatorFunc[TInput] {
+	return func(context.Context, TInput) error {
+		*calls = append(*calls, path)
+		return Violations{{Path: path, Code: "invalid", Message: "invalid"}}
+	}
+}
+
+func TestAddValidator(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("validators are called in order before the handler", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		calls := []string{}
+		AddValidatorOn[string](m, ValidatorFunc[string](func(context.Context, string) error {
+			calls = append(calls, "first")
+			return nil
+		}))
+		AddValidatorOn[string](m, ValidatorFunc[string](func(context.Context, string) error {
+			calls = append(calls, "second")
+			return nil
+		}))
+		RegisterHandlerOn[string, string](m, &mockhandler[string, string]{
+			validate: func(context.Context, string) error {
+				calls = append(calls, "handler validator")
+				return nil
+			},
+			execute: func(context.Context, string) (string, error) {
+				calls = append(calls, "handler")
+				return "", nil
+			},
+		})
+
+		// ACT
+		_, err := PerformOn[string, string](m, ctx, "request")
+
+		// ASSERT
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		wanted := []string{"first", "second", "handler validator", "handler"}
+		if !reflect.DeepEqual(wanted, calls) {
+			t.Errorf("\nwanted %v\ngot    %v", wanted, calls)
+		}
+	})
+
+	t.Run("stops at the first error", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		calls := []string{}
+		AddValidatorOn[int](m, violation[int]("first", &calls))
+		AddValidatorOn[int](m, violation[int]("second", &calls))
+		receiver := accepting[int]()
+		RegisterReceiverOn[int](m, receiver)
+
+		// ACT
+		err := SendOn(m, ctx, 42)
+
+		// ASSERT
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("wanted ValidationError, got %v", err)
+		}
+		if wanted := []string{"first"}; !reflect.DeepEqual(wanted, calls) {
+			t.Errorf("wanted %v, got %v", wanted, calls)
+		}
+		if receiver.WasCalled() {
+			t.Error("receiver was called")
+		}
+	})
+
+	t.Run("aggregates errors", func(t *testing.T) {
+		// ARRANGE
+		m := New(WithAggregateValidation())
+		calls := []string{}
+		AddValidatorOn[int](m, violation[int]("first", &calls))
+		AddValidatorOn[int](m, ValidatorFunc[int](func(context.Context, int) error {
+			return errors.New("not a violation")
+		}))
+		AddValidatorOn[int](m, violation[int]("second", &calls))
+		RegisterReceiverOn[int](m, accepting[int]())
+
+		// ACT
+		err := SendOn(m, ctx, 42)
+
+		// ASSERT
+		verr := ValidationError{}
+		if !errors.As(err, &verr) {
+			t.Fatalf("wanted ValidationError, got %T (%[1]v)", err)
+		}
+		wanted := Violations{
+			{Path: "first", Code: "invalid", Message: "invalid"},
+			{Message: "not a violation"},
+			{Path: "second", Code: "invalid", Message: "invalid"},
+		}
+		if got := verr.Violations(); !reflect.DeepEqual(wanted, got) {
+			t.Errorf("\nwanted %v\ngot    %v", wanted, got)
+		}
+	})
+
+	t.Run("applies to subscribers and stream handlers", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		calls := []string{}
+		AddValidatorOn[notification](m, violation[notification]("notification", &calls))
+		AddValidatorOn[pageRequest](m, violation[pageRequest]("stream", &calls))
+		SubscribeOn[notification](m, accepting[notification]())
+		RegisterStreamHandlerOn[pageRequest, int](m, pager())
+
+		// ACT
+		errPublish := PublishOn(m, ctx, notification{})
+		s, _ := StreamOn[pageRequest, int](m, ctx, pageRequest{pages: 1})
+		for range s.Items() {
+		}
+
+		// ASSERT
+		if !errors.Is(errPublish, ErrValidation) || !errors.Is(s.Err(), ErrValidation) {
+			t.Errorf("wanted ValidationErrors, got %v and %v", errPublish, s.Err())
+		}
+	})
+
+	t.Run("removing a validator", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		calls := []string{}
+		reg := AddValidatorOn[int](m, violation[int]("removed", &calls))
+		RegisterReceiverOn[int](m, accepting[int]())
+
+		// ACT
+		reg.Remove()
+		err := SendOn(m, ctx, 42)
+
+		// ASSERT
+		if err != nil || len(calls) > 0 {
+			t.Errorf("wanted no validation, got %v (calls %v)", err, calls)
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package mediator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// requirement identifies a request (or data) type for which a handler
+// (or receiver) must be registered, and the required result type of
+// a handler.
+type requirement struct {
+	kind        Kind
+	requesttype reflect.Type
+	resulttype  reflect.Type
+}
+
+// Require declares that a handler for the specified request type,
+// returning the specified result type, must be registered with the
+// default Mediator.  The requirement is checked by Verify.
+func Require[TRequest any, TResult any]() {
+	RequireOn[TRequest, TResult](defaultMediator)
+}
+
+// RequireOn declares that a handler for the specified request type,
+// returning the specified result type, must be registered with the
+// specified Mediator.  The requirement is checked by Verify.
+func RequireOn[TRequest any, TResult any](m *Mediator) {
+	m.require(requirement{
+		kind:        HandlerKind,
+		requesttype: typeOf[TRequest](),
+		resulttype:  typeOf[TResult](),
+	})
+}
+
+// RequireReceiver declares that a receiver for the specified data type
+// must be registered with the default Mediator.  The requirement is
+// checked by Verify.
+func RequireReceiver[TData any]() {
+	RequireReceiverOn[TData](defaultMediator)
+}
+
+// RequireReceiverOn declares that a receiver for the specified data type
+// must be registered with the specified Mediator.  The requirement is
+// checked by Verify.
+func RequireReceiverOn[TData any](m *Mediator) {
+	m.require(requirement{
+		kind:        ReceiverKind,
+		requesttype: typeOf[TData](),
+	})
+}
+
+// require adds a requirement to the Mediator, if not already required
+func (m *Mediator) require(req requirement) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, existing := range m.requirements {
+		if existing == req {
+			return
+		}
+	}
+	m.requirements = append(m.requirements, req)
+}
+
+// Verify checks that all requirements declared for the default Mediator
+// are satisfied.
+func Verify() error {
+	return defaultMediator.Verify()
+}
+
+// Verify checks that all requirements declared for the Mediator (using
+// Require or RequireReceiver) are satisfied, i.e. that a handler or
+// receiver is registered for each required type and that each handler
+// returns the required result type.
+//
+// If any requirements are not satisfied, a VerificationError is returned
+// identifying every unsatisfied requirement, otherwise nil.
+//
+// Handlers and receivers are resolved in the same way as when performing
+// requests or sending data, including any polymorphic dispatch.
+func (m *Mediator) Verify() error {
+	m.mu.Lock()
+	requirements := append([]requirement{}, m.requirements...)
+	m.mu.Unlock()
+
+	errs := []error{}
+	for _, req := range requirements {
+		if err := m.verify(req); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return VerificationError{errors: errs}
+	}
+	return nil
+}
+
+// verify returns an error if the specified requirement is not satisfied
+func (m *Mediator) verify(req requirement) error {
+	r := m.handlers
+	if req.kind == ReceiverKind {
+		r = m.receivers
+	}
+
+	rg, ok := r.get(req.requesttype)
+	if !ok && req.requesttype.Kind() != reflect.Interface {
+		// resolve using a sample value of the required type
+		sample := reflect.Zero(req.requesttype)
+		if req.requesttype.Kind() == reflect.Ptr {
+			sample = reflect.New(req.requesttype.Elem())
+		}
+
+		var err error
+		if rg, _, err = m.resolve(r, sample.Interface()); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case rg == nil && req.kind == ReceiverKind:
+		return NoReceiverError{datatype: req.requesttype}
+	case rg == nil:
+		return NoHandlerError{requesttype: req.requesttype}
+	case req.kind == HandlerKind && rg.resulttype != req.resulttype:
+		return InvalidHandlerError{
+			handlertype: reflect.TypeOf(rg.implementation),
+			requesttype: req.requesttype,
+			resulttype:  req.resulttype,
+		}
+	}
+	return nil
+}
+
+// VerificationError is returned by Verify if one or more requirements
+// are not satisfied.
+type VerificationError struct {
+	errors []error
+}
+
+func (e VerificationError) Error() string {
+	msgs := make([]string, len(e.errors))
+	for i, err := range e.errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("verification failed: %d requirement(s) not satisfied: %s", len(e.errors), strings.Join(msgs, "; "))
+}
+
+// Errors returns an error for each requirement that is not satisfied.
+func (e VerificationError) Errors() []error {
+	return append([]error{}, e.errors...)
+}
+
+// Unwrap returns an error for each requirement that is not satisfied.
+func (e VerificationError) Unwrap() []error {
+	return e.Errors()
+}
@@ -0,0 +1,106 @@
+package mediator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	t.Run("no requirements", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+
+		// ACT
+		err := m.Verify()
+
+		// ASSERT
+		if err != nil {
+			t.Errorf("wanted nil, got %v", err)
+		}
+	})
+
+	t.Run("requirements satisfied", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		RequireOn[string, string](m)
+		RequireReceiverOn[int](m)
+		RegisterHandlerOn[string, string](m, returning[string]("ok"))
+		RegisterReceiverOn[int](m, &mockreceiver[int]{})
+
+		// ACT
+		err := m.Verify()
+
+		// ASSERT
+		if err != nil {
+			t.Errorf("wanted nil, got %v", err)
+		}
+	})
+
+	t.Run("requirements not satisfied", func(t *testing.T) {
+		// ARRANGE
+		m := New()
+		RequireOn[string, int](m)
+		RequireOn[int, string](m)
+		RequireOn[int, string](m) // duplicate requirements are ignored
+		RequireReceiverOn[float64](m)
+		RegisterHandlerOn[string, string](m, returning[string]("ok"))
+
+		// ACT
+		err := m.Verify()
+
+		// ASSERT
+		verr, ok := err.(VerificationError)
+		if !ok {
+			t.Fatalf("wanted VerificationError, got %T (%[1]v)", err)
+		}
+		if wanted, got := 3, len(verr.Errors()); wanted != got {
+			t.Fatalf("wanted %d errors, got %d: %v", wanted, got, err)
+		}
+
+		var invalid InvalidHandlerError
+		if !errors.As(err, &invalid) {
+			t.Errorf("wanted InvalidHandlerError, got %v", err)
+		}
+		var nohandler NoHandlerError
+		if !errors.As(err, &nohandler) {
+			t.Errorf("wanted NoHandlerError, got %v", err)
+		}
+		var noreceiver NoReceiverError
+		if !errors.As(err, &noreceiver) {
+			t.Errorf("wanted NoReceiverError, got %v", err)
+		}
+	})
+
+	t.Run("polymorphic handler", func(t *testing.T) {
+		// ARRANGE
+		m := New(WithPolymorphicDispatch())
+		RequireOn[square, string](m)
+		RequireOn[*square, string](m)
+		RegisterHandlerOn[shape, string](m, returning[shape]("shape"))
+
+		// ACT
+		err := m.Verify()
+
+		// ASSERT
+		if err != nil {
+			t.Errorf("wanted nil, got %v", err)
+		}
+	})
+
+	t.Run("default mediator", func(t *testing.T) {
+		// ARRANGE
+		og := defaultMediator
+		defer func() { defaultMediator = og }()
+		defaultMediator = New()
+		Require[string, string]()
+		RequireReceiver[string]()
+
+		// ACT
+		err := Verify()
+
+		// ASSERT
+		if err == nil {
+			t.Error("wanted error, got nil")
+		}
+	})
+}
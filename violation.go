@@ -0,0 +1,149 @@
+package mediator
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ProblemDetailsContentType is the media type of a ProblemDetails
+// rendered as JSON (RFC 7807).
+const ProblemDetailsContentType = "application/problem+json"
+
+// Violation describes a validation failure relating to a single field
+// (or the input as a whole, if the Path is empty).
+type Violation struct {
+	// Path identifies the field that failed validation, e.g.
+	// "address.postcode" or "items[2].quantity".
+	Path string `json:"path,omitempty"`
+
+	// Code is a machine-readable identifier of the failure, e.g.
+	// "required" or "max".
+	Code string `json:"code,omitempty"`
+
+	// Message is a human-readable description of the failure.
+	Message string `json:"message"`
+
+	// Params holds any values relevant to the failure, e.g. the maximum
+	// permitted length of a string.
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Message
+	}
+	return v.Path + ": " + v.Message
+}
+
+// Violations is a list of validation failures, built incrementally by a
+// Validator:
+//
+//	func (FooHandler) Validate(ctx context.Context, rq FooRequest) error {
+//	    violations := mediator.Violations{}
+//	    if rq.Name == "" {
+//	        violations.Add("name", "required", "name is required")
+//	    }
+//	    if len(rq.Tags) > 5 {
+//	        violations.AddWithParams("tags", "max", "too many tags", map[string]interface{}{"max": 5})
+//	    }
+//	    return violations.Err()
+//	}
+//
+// Violations is an error that is recognised as a ValidationError by
+// errors.As, so Violations returned by a Validator are not wrapped.
+type Violations []Violation
+
+// Add adds a violation with the specified path, code and message.
+func (v *Violations) Add(path, code, message string) {
+	*v = append(*v, Violation{Path: path, Code: code, Message: message})
+}
+
+// AddWithParams adds a violation with the specified path, code, message
+// and params.
+func (v *Violations) AddWithParams(path, code, message string, params map[string]interface{}) {
+	*v = append(*v, Violation{Path: path, Code: code, Message: message, Params: params})
+}
+
+// Err returns a ValidationError with the violations, or nil if there
+// are no violations.
+func (v Violations) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return ValidationError{v}
+}
+
+func (v Violations) Error() string {
+	if len(v) == 0 {
+		return "no violations"
+	}
+	msgs := make([]string, len(v))
+	for i, violation := range v {
+		msgs[i] = violation.String()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is returns true if the target is ErrValidation.
+func (v Violations) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// As sets a target *ValidationError to a ValidationError wrapping the
+// violations.
+func (v Violations) As(target interface{}) bool {
+	if t, ok := target.(*ValidationError); ok {
+		*t = ValidationError{v}
+		return true
+	}
+	return false
+}
+
+// Violations returns the violations wrapped by the ValidationError, or
+// nil if the wrapped error does not provide Violations.
+func (e ValidationError) Violations() Violations {
+	var v Violations
+	if e.error != nil && errors.As(e.error, &v) {
+		return v
+	}
+	return nil
+}
+
+// MarshalJSON renders a ValidationError as a JSON object with the error
+// message and any violations.
+func (e ValidationError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Error      string      `json:"error"`
+		Violations []Violation `json:"violations,omitempty"`
+	}{
+		Error:      e.Error(),
+		Violations: e.Violations(),
+	})
+}
+
+// ProblemDetails describes an error in the form of RFC 7807 problem
+// details, extended with any validation violations.
+type ProblemDetails struct {
+	Type       string      `json:"type,omitempty"`
+	Title      string      `json:"title,omitempty"`
+	Status     int         `json:"status,omitempty"`
+	Detail     string      `json:"detail,omitempty"`
+	Instance   string      `json:"instance,omitempty"`
+	Violations []Violation `json:"violations,omitempty"`
+}
+
+// ProblemDetails returns RFC 7807 problem details describing the
+// ValidationError as a 400 Bad Request.  The Type and Instance are not
+// set and may be supplied by the caller if required.
+func (e ValidationError) ProblemDetails() ProblemDetails {
+	pd := ProblemDetails{
+		Title:      "Bad Request",
+		Status:     400,
+		Violations: e.Violations(),
+	}
+	if e.error != nil {
+		pd.Detail = e.error.Error()
+	}
+	return pd
+}
@@ -0,0 +1,106 @@
+package mediator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestViolations(t *testing.T) {
+	t.Run("no violations", func(t *testing.T) {
+		violations := Violations{}
+		if err := violations.Err(); err != nil {
+			t.Errorf("wanted nil, got %v", err)
+		}
+	})
+
+	t.Run("violations", func(t *testing.T) {
+		// ARRANGE
+		violations := Violations{}
+
+		// ACT
+		violations.Add("name", "required", "name is required")
+		violations.AddWithParams("tags", "max", "too many tags", map[string]interface{}{"max": 5})
+		err := violations.Err()
+
+		// ASSERT
+		verr := ValidationError{}
+		if !errors.As(err, &verr) {
+			t.Fatalf("wanted ValidationError, got %T", err)
+		}
+		if !errors.Is(err, ErrValidation) {
+			t.Error("wanted errors.Is(err, ErrValidation)")
+		}
+		if wanted, got := "validation error: name: name is required; tags: too many tags", err.Error(); wanted != got {
+			t.Errorf("wanted %q, got %q", wanted, got)
+		}
+		if wanted, got := 2, len(verr.Violations()); wanted != got {
+			t.Errorf("wanted %d violations, got %d", wanted, got)
+		}
+	})
+}
+
+func TestViolationsReturnedByValidator(t *testing.T) {
+	// ARRANGE
+	m := New()
+	RegisterReceiverOn[string](m, &mockreceiver[string]{
+		validate: func(context.Context, string) error {
+			return Violations{{Path: "data", Code: "invalid", Message: "invalid data"}}
+		},
+	})
+
+	// ACT
+	err := SendOn(m, context.Background(), "data")
+
+	// ASSERT
+	if _, ok := err.(Violations); !ok {
+		t.Errorf("wanted (unwrapped) Violations, got %T (%[1]v)", err)
+	}
+	verr := ValidationError{}
+	if !errors.As(err, &verr) || len(verr.Violations()) != 1 {
+		t.Errorf("wanted ValidationError with 1 violation, got %v", err)
+	}
+}
+
+func TestValidationErrorJSON(t *testing.T) {
+	violations := Violations{}
+	violations.AddWithParams("tags", "max", "too many tags", map[string]interface{}{"max": 5})
+
+	testcases := []struct {
+		name   string
+		err    ValidationError
+		render func(ValidationError) interface{}
+		wanted string
+	}{
+		{name: "error with violations",
+			err:    ValidationError{violations},
+			render: func(e ValidationError) interface{} { return e },
+			wanted: `{"error":"validation error: tags: too many tags","violations":[{"path":"tags","code":"max","message":"too many tags","params":{"max":5}}]}`,
+		},
+		{name: "error without violations",
+			err:    ValidationError{errors.New("bad request")},
+			render: func(e ValidationError) interface{} { return e },
+			wanted: `{"error":"validation error: bad request"}`,
+		},
+		{name: "problem details",
+			err:    ValidationError{violations},
+			render: func(e ValidationError) interface{} { return e.ProblemDetails() },
+			wanted: `{"title":"Bad Request","status":400,"detail":"tags: too many tags","violations":[{"path":"tags","code":"max","message":"too many tags","params":{"max":5}}]}`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			// ACT
+			b, err := json.Marshal(tc.render(tc.err))
+
+			// ASSERT
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := string(b); got != tc.wanted {
+				t.Errorf("\nwanted %s\ngot    %s", tc.wanted, got)
+			}
+		})
+	}
+}